@@ -44,17 +44,39 @@ type File struct {
 	Data []byte
 }
 
+// FileModer is a WritableAsset whose files are not all written with the
+// default mode of 0644.
+type FileModer interface {
+	WritableAsset
+
+	// FileModes returns the modes of the files, by file name, that are not
+	// written with the default mode.
+	FileModes() map[string]os.FileMode
+}
+
 // PersistToFile writes all of the files of the specified asset into the specified
 // directory.
 func PersistToFile(asset WritableAsset, directory string) error {
+	var modes map[string]os.FileMode
+	if moder, ok := asset.(FileModer); ok {
+		modes = moder.FileModes()
+	}
 	for _, f := range asset.Files() {
 		path := filepath.Join(directory, f.Filename)
 		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
 			return errors.Wrap(err, "failed to create dir")
 		}
-		if err := ioutil.WriteFile(path, f.Data, 0644); err != nil {
+		mode, ok := modes[f.Filename]
+		if !ok {
+			mode = 0644
+		}
+		if err := ioutil.WriteFile(path, f.Data, mode); err != nil {
 			return errors.Wrap(err, "failed to write file")
 		}
+		// WriteFile keeps the mode of a file that already exists.
+		if err := os.Chmod(path, mode); err != nil {
+			return errors.Wrap(err, "failed to set file mode")
+		}
 	}
 	return nil
 }
@@ -86,6 +86,46 @@ func TestPersistToFile(t *testing.T) {
 	}
 }
 
+type modedPersistAsset struct {
+	writablePersistAsset
+	modes map[string]os.FileMode
+}
+
+func (a *modedPersistAsset) FileModes() map[string]os.FileMode {
+	return a.modes
+}
+
+func TestPersistToFileModes(t *testing.T) {
+	dir, err := ioutil.TempDir("", "TestPersistToFileModes")
+	if err != nil {
+		t.Skipf("could not create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	// An existing file takes the mode of the asset file.
+	if err := ioutil.WriteFile(filepath.Join(dir, "secret"), []byte("old"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	asset := &modedPersistAsset{
+		writablePersistAsset: writablePersistAsset{
+			FileList: []*File{
+				{Filename: "secret", Data: []byte("secret")},
+				{Filename: "public", Data: []byte("public")},
+			},
+		},
+		modes: map[string]os.FileMode{"secret": 0600},
+	}
+	if !assert.NoError(t, PersistToFile(asset, dir), "unexpected error persisting files") {
+		return
+	}
+	for filename, expected := range map[string]os.FileMode{"secret": 0600, "public": 0644} {
+		info, err := os.Stat(filepath.Join(dir, filename))
+		if assert.NoError(t, err) {
+			assert.Equal(t, expected, info.Mode().Perm(), "unexpected mode for %s", filename)
+		}
+	}
+}
+
 func verifyFilesCreated(t *testing.T, dir string, expectedFiles map[string][]byte) {
 	dirContents, err := ioutil.ReadDir(dir)
 	assert.NoError(t, err, "could not read contents of directory %q", dir)
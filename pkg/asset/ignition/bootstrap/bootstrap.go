@@ -200,10 +200,18 @@ func (a *Bootstrap) Files() []*asset.File {
 
 // getTemplateData returns the data to use to execute bootstrap templates.
 func (a *Bootstrap) getTemplateData(installConfig *types.InstallConfig, releaseImage string, imageSources []types.ImageContentSource, proxy *configv1.Proxy, rhcosImage *rhcos.Image) (*bootstrapTemplateData, error) {
-	etcdEndpoints := make([]string, *installConfig.ControlPlane.Replicas)
+	etcdHostnames := manifests.EtcdEndpointHostnames(installConfig)
+	etcdEndpoints := make([]string, len(etcdHostnames))
 
-	for i := range etcdEndpoints {
-		etcdEndpoints[i] = fmt.Sprintf("https://etcd-%d.%s:2379", i, installConfig.ClusterDomain())
+	for i, hostname := range etcdHostnames {
+		etcdEndpoints[i] = fmt.Sprintf("https://%s.%s:2379", hostname, installConfig.EtcdDiscoveryDomain())
+	}
+
+	// The bootstrap node pulls from the mirrors too, so it needs the same
+	// credentials as the cluster.
+	pullSecret, err := manifests.MirrorPullSecret(installConfig.PullSecret, imageSources)
+	if err != nil {
+		return nil, err
 	}
 
 	registries := []sysregistriesv2.Registry{}
@@ -223,7 +231,7 @@ func (a *Bootstrap) getTemplateData(installConfig *types.InstallConfig, releaseI
 
 	return &bootstrapTemplateData{
 		AdditionalTrustBundle: installConfig.AdditionalTrustBundle,
-		PullSecret:            installConfig.PullSecret,
+		PullSecret:            pullSecret,
 		ReleaseImage:          releaseImage,
 		EtcdCluster:           strings.Join(etcdEndpoints, ","),
 		Proxy:                 &proxy.Status,
@@ -413,8 +421,16 @@ func readFile(name string, reader io.Reader, templateData interface{}) (finalNam
 func (a *Bootstrap) addParentFiles(dependencies asset.Parents) {
 	// These files are all added with mode 0644, i.e. readable
 	// by all processes on the system.
+	mfsts := &manifests.Manifests{}
+	dependencies.Get(mfsts)
+	for _, f := range mfsts.Files() {
+		// The checksums are not a cluster object.
+		if mfsts.IsChecksumsFile(f) {
+			continue
+		}
+		a.Config.Storage.Files = append(a.Config.Storage.Files, ignition.FileFromBytes(filepath.Join(rootDir, f.Filename), "root", 0644, f.Data))
+	}
 	for _, asset := range []asset.WritableAsset{
-		&manifests.Manifests{},
 		&manifests.Openshift{},
 		&machines.Master{},
 		&machines.Worker{},
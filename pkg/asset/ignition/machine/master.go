@@ -29,6 +29,7 @@ func (a *Master) Dependencies() []asset.Asset {
 	return []asset.Asset{
 		&installconfig.InstallConfig{},
 		&tls.RootCA{},
+		&tls.MCSSignerCertKey{},
 	}
 }
 
@@ -36,9 +37,10 @@ func (a *Master) Dependencies() []asset.Asset {
 func (a *Master) Generate(dependencies asset.Parents) error {
 	installConfig := &installconfig.InstallConfig{}
 	rootCA := &tls.RootCA{}
-	dependencies.Get(installConfig, rootCA)
+	mcsSigner := &tls.MCSSignerCertKey{}
+	dependencies.Get(installConfig, rootCA, mcsSigner)
 
-	a.Config = pointerIgnitionConfig(installConfig.Config, rootCA.Cert(), "master")
+	a.Config = pointerIgnitionConfig(installConfig.Config, tls.MCSCA(rootCA, mcsSigner), "master")
 
 	data, err := json.Marshal(a.Config)
 	if err != nil {
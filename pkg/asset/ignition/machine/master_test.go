@@ -43,7 +43,7 @@ func TestMasterGenerate(t *testing.T) {
 	assert.NoError(t, err, "unexpected error generating root CA")
 
 	parents := asset.Parents{}
-	parents.Add(installConfig, rootCA)
+	parents.Add(installConfig, rootCA, &tls.MCSSignerCertKey{})
 
 	master := &Master{}
 	err = master.Generate(parents)
@@ -14,8 +14,8 @@ import (
 )
 
 // pointerIgnitionConfig generates a config which references the remote config
-// served by the machine config server.
-func pointerIgnitionConfig(installConfig *types.InstallConfig, rootCA []byte, role string) *ignition.Config {
+// served by the machine config server, trusting mcsCA to serve it.
+func pointerIgnitionConfig(installConfig *types.InstallConfig, mcsCA []byte, role string) *ignition.Config {
 	var ignitionHost string
 	switch installConfig.Platform.Name() {
 	case baremetaltypes.Name:
@@ -50,7 +50,7 @@ func pointerIgnitionConfig(installConfig *types.InstallConfig, rootCA []byte, ro
 			Security: ignition.Security{
 				TLS: ignition.TLS{
 					CertificateAuthorities: []ignition.CaReference{{
-						Source: dataurl.EncodeBytes(rootCA),
+						Source: dataurl.EncodeBytes(mcsCA),
 					}},
 				},
 			},
@@ -33,7 +33,7 @@ func TestWorkerGenerate(t *testing.T) {
 	assert.NoError(t, err, "unexpected error generating root CA")
 
 	parents := asset.Parents{}
-	parents.Add(installConfig, rootCA)
+	parents.Add(installConfig, rootCA, &tls.MCSSignerCertKey{})
 
 	worker := &Worker{}
 	err = worker.Generate(parents)
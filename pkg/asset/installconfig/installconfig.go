@@ -118,6 +118,12 @@ func (a *InstallConfig) Load(f asset.FileFetcher) (found bool, err error) {
 		return false, errors.Wrap(err, "failed to upconvert install config")
 	}
 
+	// The secret is read once, so the state holds it and later phases do
+	// not depend on the file or on the working directory.
+	if err := resolvePullSecretFile(a.Config, f); err != nil {
+		return false, errors.Wrapf(err, "invalid %q file", installConfigFilename)
+	}
+
 	err = a.finish(installConfigFilename)
 	if err != nil {
 		return false, err
@@ -2,7 +2,9 @@ package installconfig
 
 import (
 	"errors"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -269,3 +271,87 @@ network:
 		})
 	}
 }
+
+func TestInstallConfigLoadPullSecretFile(t *testing.T) {
+	const pullSecret = `{"auths":{"example.com":{"auth":"authorization value"}}}`
+	dir, err := ioutil.TempDir("", "pull-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	absolutePath := filepath.Join(dir, "pull-secret.json")
+	if err := ioutil.WriteFile(absolutePath, []byte(pullSecret+"\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name          string
+		path          string
+		file          *asset.File
+		fetchError    error
+		expectedError string
+	}{
+		{
+			name: "relative to the install-config",
+			path: "pull-secret.json",
+			file: &asset.File{Filename: "pull-secret.json", Data: []byte(pullSecret + "\n")},
+		},
+		{
+			name: "absolute",
+			path: absolutePath,
+		},
+		{
+			name:          "missing",
+			path:          "missing.json",
+			fetchError:    &os.PathError{Op: "open", Path: "missing.json", Err: os.ErrNotExist},
+			expectedError: "failed to read the pull secret",
+		},
+		{
+			name:          "invalid",
+			path:          "pull-secret.json",
+			file:          &asset.File{Filename: "pull-secret.json", Data: []byte("not a pull secret")},
+			expectedError: "pullSecret: Invalid value",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			fileFetcher.EXPECT().FetchByName(installConfigFilename).
+				Return(
+					&asset.File{
+						Filename: installConfigFilename,
+						Data: []byte(`
+apiVersion: v1
+metadata:
+  name: test-cluster
+baseDomain: test-domain
+platform:
+  aws:
+    region: us-east-1
+pullSecret: "@` + tc.path + `"
+`)},
+					nil,
+				)
+			if !filepath.IsAbs(tc.path) {
+				fileFetcher.EXPECT().FetchByName(tc.path).Return(tc.file, tc.fetchError)
+			}
+
+			ic := &InstallConfig{}
+			_, err := ic.Load(fileFetcher)
+			if tc.expectedError != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tc.expectedError)
+				}
+				return
+			}
+			if assert.NoError(t, err) {
+				// The secret itself is kept, so it is not read again.
+				assert.Equal(t, pullSecret, ic.Config.PullSecret)
+				assert.NotContains(t, string(ic.File.Data), tc.path)
+			}
+		})
+	}
+}
@@ -1,9 +1,15 @@
 package installconfig
 
 import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
 	survey "gopkg.in/AlecAivazis/survey.v1"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
 	"github.com/openshift/installer/pkg/validate"
 )
 
@@ -37,3 +43,28 @@ func (a *pullSecret) Generate(asset.Parents) error {
 func (a *pullSecret) Name() string {
 	return "Pull Secret"
 }
+
+// resolvePullSecretFile replaces a pull secret given as a file reference
+// with the secret read from the file, without its surrounding whitespace.
+// Relative paths are relative to the directory of the install-config.
+func resolvePullSecretFile(config *types.InstallConfig, f asset.FileFetcher) error {
+	path := config.PullSecretFile()
+	if path == "" {
+		return nil
+	}
+	var data []byte
+	if filepath.IsAbs(path) {
+		var err error
+		if data, err = ioutil.ReadFile(path); err != nil {
+			return errors.Wrap(err, "failed to read the pull secret")
+		}
+	} else {
+		file, err := f.FetchByName(path)
+		if err != nil {
+			return errors.Wrap(err, "failed to read the pull secret")
+		}
+		data = file.Data
+	}
+	config.PullSecret = strings.TrimSpace(string(data))
+	return nil
+}
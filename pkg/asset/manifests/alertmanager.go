@@ -0,0 +1,82 @@
+package manifests
+
+import (
+	"github.com/ghodss/yaml"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// alertmanagerConfig is the part of the Alertmanager configuration the
+// installer generates.
+type alertmanagerConfig struct {
+	Global    map[string]string      `json:"global"`
+	Route     alertmanagerRoute      `json:"route"`
+	Receivers []alertmanagerReceiver `json:"receivers"`
+}
+
+type alertmanagerRoute struct {
+	Receiver       string              `json:"receiver"`
+	GroupBy        []string            `json:"group_by,omitempty"`
+	GroupWait      string              `json:"group_wait,omitempty"`
+	GroupInterval  string              `json:"group_interval,omitempty"`
+	RepeatInterval string              `json:"repeat_interval,omitempty"`
+	Match          map[string]string   `json:"match,omitempty"`
+	Routes         []alertmanagerRoute `json:"routes,omitempty"`
+}
+
+type alertmanagerReceiver struct {
+	Name         string                    `json:"name"`
+	SlackConfigs []alertmanagerSlackConfig `json:"slack_configs,omitempty"`
+	EmailConfigs []alertmanagerEmailConfig `json:"email_configs,omitempty"`
+}
+
+type alertmanagerSlackConfig struct {
+	APIURL  string `json:"api_url"`
+	Channel string `json:"channel"`
+}
+
+type alertmanagerEmailConfig struct {
+	To           string `json:"to"`
+	From         string `json:"from"`
+	Smarthost    string `json:"smarthost"`
+	AuthUsername string `json:"auth_username,omitempty"`
+	AuthPassword string `json:"auth_password,omitempty"`
+}
+
+// alertmanagerConfigYAML returns the alertmanager.yaml of the
+// alertmanager-main secret, which sends every alert to the receiver, except
+// for the Watchdog alert that always fires to show that alerting works. It
+// keeps the grouping of the default configuration of the cluster.
+func alertmanagerConfigYAML(receiver *types.AlertReceiver) ([]byte, error) {
+	defaultReceiver := alertmanagerReceiver{Name: "default"}
+	if s := receiver.Slack; s != nil {
+		defaultReceiver.SlackConfigs = []alertmanagerSlackConfig{{
+			APIURL:  s.APIURL,
+			Channel: s.Channel,
+		}}
+	}
+	if e := receiver.Email; e != nil {
+		defaultReceiver.EmailConfigs = []alertmanagerEmailConfig{{
+			To:           e.To,
+			From:         e.From,
+			Smarthost:    e.Smarthost,
+			AuthUsername: e.AuthUsername,
+			AuthPassword: e.AuthPassword,
+		}}
+	}
+	return yaml.Marshal(&alertmanagerConfig{
+		Global: map[string]string{"resolve_timeout": "5m"},
+		Route: alertmanagerRoute{
+			Receiver:       defaultReceiver.Name,
+			GroupBy:        []string{"namespace"},
+			GroupWait:      "30s",
+			GroupInterval:  "5m",
+			RepeatInterval: "12h",
+			Routes: []alertmanagerRoute{{
+				Receiver: "watchdog",
+				Match:    map[string]string{"alertname": "Watchdog"},
+			}},
+		},
+		Receivers: []alertmanagerReceiver{defaultReceiver, {Name: "watchdog"}},
+	})
+}
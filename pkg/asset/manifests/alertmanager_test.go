@@ -0,0 +1,66 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+var alertmanagerMainSecretFilename = filepath.Join(manifestDir, "alertmanager-main-secret.yaml")
+
+func TestManifestsAlertReceiver(t *testing.T) {
+	m := &Manifests{}
+	generateTestAsset(t, testInstallConfig(), m)
+	assert.NotContains(t, m.FileMap(), alertmanagerMainSecretFilename, "unexpected Alertmanager configuration without a receiver")
+
+	ic := testInstallConfig()
+	ic.AlertReceiver = &types.AlertReceiver{
+		Slack: &types.SlackAlertReceiver{
+			APIURL:  "https://hooks.slack.com/services/T0/B0/XXXX",
+			Channel: "#alerts",
+		},
+	}
+	m = &Manifests{}
+	generateTestAsset(t, ic, m)
+	f, ok := m.FileMap()[alertmanagerMainSecretFilename]
+	if !assert.True(t, ok, "missing Alertmanager configuration") {
+		return
+	}
+	var secret corev1.Secret
+	if err := yaml.Unmarshal(f.Data, &secret); err != nil {
+		t.Fatalf("failed to unmarshal the alertmanager-main secret: %v", err)
+	}
+	assert.Equal(t, "openshift-monitoring", secret.Namespace)
+	var config alertmanagerConfig
+	if err := yaml.Unmarshal(secret.Data["alertmanager.yaml"], &config); err != nil {
+		t.Fatalf("failed to unmarshal alertmanager.yaml: %v", err)
+	}
+	assert.Equal(t, "default", config.Route.Receiver)
+	assert.Equal(t, []alertmanagerReceiver{
+		{
+			Name: "default",
+			SlackConfigs: []alertmanagerSlackConfig{{
+				APIURL:  "https://hooks.slack.com/services/T0/B0/XXXX",
+				Channel: "#alerts",
+			}},
+		},
+		{Name: "watchdog"},
+	}, config.Receivers)
+	if assert.Len(t, config.Route.Routes, 1, "unexpected routes") {
+		assert.Equal(t, "watchdog", config.Route.Routes[0].Receiver)
+	}
+
+	// The webhook URL is a credential, so it is not kept in the cluster-config
+	// configmap.
+	redacted := &types.InstallConfig{}
+	if err := yaml.Unmarshal([]byte(m.KubeSysConfig.Data["install-config"]), redacted); err != nil {
+		t.Fatalf("failed to unmarshal the redacted install-config: %v", err)
+	}
+	assert.Equal(t, &types.SlackAlertReceiver{Channel: "#alerts"}, redacted.AlertReceiver.Slack)
+	assert.Equal(t, "https://hooks.slack.com/services/T0/B0/XXXX", ic.AlertReceiver.Slack.APIURL, "install config was unexpectedly modified")
+}
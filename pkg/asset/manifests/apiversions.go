@@ -0,0 +1,60 @@
+package manifests
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// defaultKubernetesVersion is the Kubernetes release of the control plane
+// the manifests target by default.
+const defaultKubernetesVersion = "1.16"
+
+// KubernetesAPIVersions are the apiVersions of the generated objects whose
+// API group version depends on the Kubernetes release of the control plane.
+type KubernetesAPIVersions struct {
+	CronJob             string
+	PodDisruptionBudget string
+}
+
+// kubernetesAPIVersions returns the apiVersions for the target Kubernetes
+// release, given as major.minor, defaulting to defaultKubernetesVersion.
+// Releases from 1.16, the oldest supported by the manifests, to 1.24, the
+// last serving every apiVersion the manifests use, are supported.
+func kubernetesAPIVersions(target string) (KubernetesAPIVersions, error) {
+	if target == "" {
+		target = defaultKubernetesVersion
+	}
+	minor, err := kubernetesMinorVersion(target)
+	if err != nil {
+		return KubernetesAPIVersions{}, err
+	}
+	if minor < 16 || minor > 24 {
+		return KubernetesAPIVersions{}, errors.Errorf("unsupported target Kubernetes version %q", target)
+	}
+
+	versions := KubernetesAPIVersions{
+		CronJob:             "batch/v1beta1",
+		PodDisruptionBudget: "policy/v1beta1",
+	}
+	if minor >= 21 {
+		versions.CronJob = "batch/v1"
+		versions.PodDisruptionBudget = "policy/v1"
+	}
+	return versions, nil
+}
+
+// kubernetesMinorVersion returns the minor version of a 1.x release, which
+// may carry a leading v, such as v1.18.
+func kubernetesMinorVersion(version string) (int, error) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) != 2 || parts[0] != "1" {
+		return 0, errors.Errorf("invalid target Kubernetes version %q, which must be of the form 1.x", version)
+	}
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil || minor < 0 {
+		return 0, errors.Errorf("invalid target Kubernetes version %q, which must be of the form 1.x", version)
+	}
+	return minor, nil
+}
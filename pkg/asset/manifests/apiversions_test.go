@@ -0,0 +1,98 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+)
+
+func TestKubernetesAPIVersions(t *testing.T) {
+	cases := []struct {
+		target        string
+		expected      KubernetesAPIVersions
+		expectedError string
+	}{
+		{
+			target:   "",
+			expected: KubernetesAPIVersions{CronJob: "batch/v1beta1", PodDisruptionBudget: "policy/v1beta1"},
+		},
+		{
+			target:   "1.16",
+			expected: KubernetesAPIVersions{CronJob: "batch/v1beta1", PodDisruptionBudget: "policy/v1beta1"},
+		},
+		{
+			target:   "v1.20",
+			expected: KubernetesAPIVersions{CronJob: "batch/v1beta1", PodDisruptionBudget: "policy/v1beta1"},
+		},
+		{
+			target:   "1.21",
+			expected: KubernetesAPIVersions{CronJob: "batch/v1", PodDisruptionBudget: "policy/v1"},
+		},
+		{
+			target:   "1.24",
+			expected: KubernetesAPIVersions{CronJob: "batch/v1", PodDisruptionBudget: "policy/v1"},
+		},
+		{
+			target:        "1.15",
+			expectedError: `unsupported target Kubernetes version "1.15"`,
+		},
+		{
+			target:        "1.25",
+			expectedError: `unsupported target Kubernetes version "1.25"`,
+		},
+		{
+			target:        "2.0",
+			expectedError: `invalid target Kubernetes version "2.0", which must be of the form 1.x`,
+		},
+		{
+			target:        "1.18.3",
+			expectedError: `invalid target Kubernetes version "1.18.3", which must be of the form 1.x`,
+		},
+		{
+			target:        "latest",
+			expectedError: `invalid target Kubernetes version "latest", which must be of the form 1.x`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.target, func(t *testing.T) {
+			versions, err := kubernetesAPIVersions(tc.target)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, versions)
+			}
+		})
+	}
+}
+
+func TestManifestsTargetKubernetesVersion(t *testing.T) {
+	ic := testInstallConfig()
+	ic.ControlPlane.Replicas = pointer.Int64Ptr(3)
+	pdbAPIVersion := func(target string) string {
+		m := &Manifests{TargetKubernetesVersion: target}
+		generateTestAsset(t, ic, m)
+		f := m.FileMap()[etcdPodDisruptionBudgetFilename]
+		if f == nil {
+			t.Fatalf("%s was not generated", etcdPodDisruptionBudgetFilename)
+		}
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(f.Data, &typeMeta); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", f.Filename, err)
+		}
+		return typeMeta.APIVersion
+	}
+
+	assert.Equal(t, "policy/v1beta1", pdbAPIVersion("1.16"))
+	assert.Equal(t, "policy/v1", pdbAPIVersion("1.21"))
+}
+
+func TestManifestsUnsupportedTargetKubernetesVersion(t *testing.T) {
+	m := &Manifests{TargetKubernetesVersion: "1.30"}
+	err := m.Generate(generateTestParents(t, testInstallConfig(), m))
+	assert.EqualError(t, err, `unsupported target Kubernetes version "1.30"`)
+}
@@ -0,0 +1,24 @@
+package manifests
+
+import (
+	"github.com/pkg/errors"
+)
+
+// base64Input is the data a field of the template data is base64-encoded
+// from.
+type base64Input struct {
+	field string
+	data  []byte
+}
+
+// validateBase64Inputs returns an error naming the first field whose input
+// is empty. Empty input encodes to an empty string, which would otherwise
+// render into a secret that is only found broken once its consumer starts.
+func validateBase64Inputs(inputs []base64Input) error {
+	for _, input := range inputs {
+		if len(input.data) == 0 {
+			return errors.Errorf("no data to base64-encode into %s", input.field)
+		}
+	}
+	return nil
+}
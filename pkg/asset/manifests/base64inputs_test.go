@@ -0,0 +1,31 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset/tls"
+)
+
+func TestValidateBase64Inputs(t *testing.T) {
+	assert.NoError(t, validateBase64Inputs(nil))
+	assert.NoError(t, validateBase64Inputs([]base64Input{{field: "Cert", data: []byte("cert")}}))
+	assert.EqualError(t, validateBase64Inputs([]base64Input{
+		{field: "Cert", data: []byte("cert")},
+		{field: "Key", data: []byte{}},
+		{field: "Other", data: nil},
+	}), "no data to base64-encode into Key")
+}
+
+func TestManifestsGenerateEmptyCert(t *testing.T) {
+	m := &Manifests{}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	mcsCertKey := &tls.MCSCertKey{}
+	parents.Get(mcsCertKey)
+	mcsCertKey.CertRaw = []byte{}
+	parents.Add(mcsCertKey)
+
+	err := m.Generate(parents)
+	assert.EqualError(t, err, "no data to base64-encode into McsTLSCert")
+}
@@ -0,0 +1,50 @@
+package manifests
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+
+	"github.com/pkg/errors"
+)
+
+// Bundle returns the manifests as a gzipped tar archive. The files keep their
+// manifests/ prefix so that the archive can be extracted into the asset
+// directory.
+func (m *Manifests) Bundle() ([]byte, error) {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for _, f := range m.FileList {
+		header := &tar.Header{
+			Name:     f.Filename,
+			Mode:     0644,
+			Size:     int64(len(f.Data)),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(header); err != nil {
+			return nil, errors.Wrapf(err, "failed to write tar header for %s", f.Filename)
+		}
+		if _, err := tw.Write(f.Data); err != nil {
+			return nil, errors.Wrapf(err, "failed to write %s to tar", f.Filename)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close tar writer")
+	}
+	if err := gw.Close(); err != nil {
+		return nil, errors.Wrap(err, "failed to close gzip writer")
+	}
+	return buf.Bytes(), nil
+}
+
+// BundleBase64 returns the bundle from Bundle encoded in base64, suitable for
+// embedding in an Ignition config.
+func (m *Manifests) BundleBase64() (string, error) {
+	bundle, err := m.Bundle()
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(bundle), nil
+}
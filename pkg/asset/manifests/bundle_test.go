@@ -0,0 +1,72 @@
+package manifests
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func unbundle(t *testing.T, bundle []byte) []*asset.File {
+	gr, err := gzip.NewReader(bytes.NewReader(bundle))
+	if err != nil {
+		t.Fatalf("failed to create gzip reader: %v", err)
+	}
+	tr := tar.NewReader(gr)
+	files := []*asset.File{}
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatalf("failed to read tar header: %v", err)
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", header.Name, err)
+		}
+		files = append(files, &asset.File{Filename: header.Name, Data: data})
+	}
+	return files
+}
+
+func TestBundle(t *testing.T) {
+	m := &Manifests{
+		FileList: []*asset.File{
+			{
+				Filename: "manifests/cluster-config.yaml",
+				Data:     []byte("apiVersion: v1\nkind: ConfigMap\n"),
+			},
+			{
+				Filename: "manifests/empty.yaml",
+				Data:     []byte{},
+			},
+			{
+				Filename: "manifests/etcd-namespace.yaml",
+				Data:     []byte("apiVersion: v1\nkind: Namespace\n"),
+			},
+		},
+	}
+
+	bundle, err := m.Bundle()
+	if assert.NoError(t, err, "unexpected error from Bundle") {
+		assert.Equal(t, m.FileList, unbundle(t, bundle), "unexpected files in bundle")
+	}
+
+	encoded, err := m.BundleBase64()
+	if !assert.NoError(t, err, "unexpected error from BundleBase64") {
+		return
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if assert.NoError(t, err, "unexpected error decoding bundle") {
+		assert.Equal(t, m.FileList, unbundle(t, decoded), "unexpected files in encoded bundle")
+	}
+}
@@ -0,0 +1,50 @@
+package manifests
+
+import (
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset/tls"
+)
+
+// certChainCheck names a certificate that must verify against a CA bundle.
+type certChainCheck struct {
+	name     string
+	cert     []byte
+	bundle   string
+	caBundle []byte
+}
+
+// verifyCertChains returns a TLSConsistencyError for the first certificate
+// that does not verify against its CA bundle. Signers loaded from disk
+// alongside regenerated bundles would otherwise only fail once etcd starts
+// serving.
+func verifyCertChains(checks []certChainCheck) error {
+	for _, check := range checks {
+		roots := x509.NewCertPool()
+		if !roots.AppendCertsFromPEM(check.caBundle) {
+			return &TLSConsistencyError{
+				Certificate: check.name,
+				CABundle:    check.bundle,
+				Err:         errors.Errorf("%s contains no certificates", check.bundle),
+			}
+		}
+		cert, err := tls.PemToCertificate(check.cert)
+		if err != nil {
+			return errors.Wrapf(err, "failed to parse %s certificate", check.name)
+		}
+		opts := x509.VerifyOptions{
+			Roots:     roots,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+		}
+		if _, err := cert.Verify(opts); err != nil {
+			return &TLSConsistencyError{
+				Certificate: check.name,
+				CABundle:    check.bundle,
+				Err:         errors.Wrapf(err, "%s certificate does not chain to %s", check.name, check.bundle),
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package manifests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/tls"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// generateEtcdCerts generates an etcd signer along with its CA bundle and
+// client cert.
+func generateEtcdCerts(t *testing.T) (*tls.EtcdSignerCertKey, *tls.EtcdCABundle, *tls.EtcdSignerClientCertKey) {
+	signer := &tls.EtcdSignerCertKey{}
+	if err := signer.Generate(nil); err != nil {
+		t.Fatalf("failed to generate etcd signer: %v", err)
+	}
+	parents := asset.Parents{}
+	parents.Add(signer, &installconfig.InstallConfig{Config: &types.InstallConfig{}})
+	bundle := &tls.EtcdCABundle{}
+	if err := bundle.Generate(parents); err != nil {
+		t.Fatalf("failed to generate etcd CA bundle: %v", err)
+	}
+	client := &tls.EtcdSignerClientCertKey{}
+	if err := client.Generate(parents); err != nil {
+		t.Fatalf("failed to generate etcd client cert: %v", err)
+	}
+	return signer, bundle, client
+}
+
+func TestVerifyCertChains(t *testing.T) {
+	signer, bundle, client := generateEtcdCerts(t)
+	_, otherBundle, otherClient := generateEtcdCerts(t)
+
+	cases := []struct {
+		name          string
+		checks        []certChainCheck
+		expectedError string
+	}{
+		{
+			name: "matched",
+			checks: []certChainCheck{
+				{name: "etcd-signer", cert: signer.Cert(), bundle: "etcd-ca-bundle", caBundle: bundle.Cert()},
+				{name: "etcd-client", cert: client.Cert(), bundle: "etcd-ca-bundle", caBundle: bundle.Cert()},
+			},
+		},
+		{
+			name: "mismatched client",
+			checks: []certChainCheck{
+				{name: "etcd-signer", cert: signer.Cert(), bundle: "etcd-ca-bundle", caBundle: bundle.Cert()},
+				{name: "etcd-client", cert: otherClient.Cert(), bundle: "etcd-ca-bundle", caBundle: bundle.Cert()},
+			},
+			expectedError: "etcd-client certificate does not chain to etcd-ca-bundle: x509: certificate signed by unknown authority",
+		},
+		{
+			name: "mismatched signer",
+			checks: []certChainCheck{
+				{name: "etcd-signer", cert: signer.Cert(), bundle: "etcd-ca-bundle", caBundle: otherBundle.Cert()},
+			},
+			expectedError: "etcd-signer certificate does not chain to etcd-ca-bundle: x509: certificate signed by unknown authority",
+		},
+		{
+			name: "empty bundle",
+			checks: []certChainCheck{
+				{name: "etcd-signer", cert: signer.Cert(), bundle: "etcd-ca-bundle"},
+			},
+			expectedError: "etcd-ca-bundle contains no certificates",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifyCertChains(tc.checks)
+			if tc.expectedError == "" {
+				assert.NoError(t, err, "unexpected error")
+				return
+			}
+			// Newer Go versions explain why the authority is unknown.
+			if assert.Error(t, err, "expected an error") {
+				assert.True(t, strings.HasPrefix(err.Error(), tc.expectedError), "unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestManifestsGenerateMismatchedEtcdCerts(t *testing.T) {
+	m := &Manifests{}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	_, _, otherClient := generateEtcdCerts(t)
+	parents.Add(otherClient)
+
+	err := m.Generate(parents)
+	if assert.Error(t, err, "expected an error for a mismatched etcd client cert") {
+		assert.Contains(t, err.Error(), "etcd-client certificate does not chain to etcd-ca-bundle")
+	}
+}
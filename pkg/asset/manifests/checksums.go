@@ -0,0 +1,61 @@
+package manifests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+var (
+	checksumsFilename = filepath.Join(manifestDir, "manifest-checksums.yaml")
+)
+
+// IsChecksumsFile tests whether a file is the checksum summary generated
+// alongside the manifests, in their directory. It is not a cluster object
+// and must not be applied to the cluster.
+func (m *Manifests) IsChecksumsFile(file *asset.File) bool {
+	return isChecksumsFile(file, m.directory())
+}
+
+// isChecksumsFile tests whether a file is the checksum summary of the
+// manifests in dir.
+func isChecksumsFile(file *asset.File, dir string) bool {
+	return file.Filename == relocatedFilename(checksumsFilename, dir)
+}
+
+// checksumsFile returns a file in dir listing the SHA-256 digest of each of
+// the given files, keyed by filename.
+func checksumsFile(files []*asset.File, dir string) (*asset.File, error) {
+	checksums := make(map[string]string, len(files))
+	for _, f := range files {
+		if isChecksumsFile(f, dir) {
+			continue
+		}
+		checksums[f.Filename] = fileChecksum(f)
+	}
+	return checksumsFileFor(checksums, dir)
+}
+
+// fileChecksum returns the hex-encoded SHA-256 digest of the file's data.
+func fileChecksum(file *asset.File) string {
+	sum := sha256.Sum256(file.Data)
+	return hex.EncodeToString(sum[:])
+}
+
+// checksumsFileFor returns a file in dir listing the given digests, keyed by
+// filename.
+func checksumsFileFor(checksums map[string]string, dir string) (*asset.File, error) {
+	data, err := yaml.Marshal(checksums)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal manifest checksums")
+	}
+	return &asset.File{
+		Filename: relocatedFilename(checksumsFilename, dir),
+		Data:     data,
+	}, nil
+}
@@ -0,0 +1,30 @@
+package manifests
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecksumsFile(t *testing.T) {
+	m := &Manifests{}
+	generateTestAsset(t, testInstallConfig(), m)
+
+	expected := map[string]string{}
+	var actual map[string]string
+	for _, f := range m.FileList {
+		if m.IsChecksumsFile(f) {
+			if !assert.NoError(t, yaml.Unmarshal(f.Data, &actual), "failed to unmarshal checksums") {
+				return
+			}
+			continue
+		}
+		sum := sha256.Sum256(f.Data)
+		expected[f.Filename] = hex.EncodeToString(sum[:])
+	}
+	assert.NotEmpty(t, expected, "no manifests generated")
+	assert.Equal(t, expected, actual, "unexpected checksums")
+}
@@ -0,0 +1,43 @@
+package manifests
+
+import (
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/sirupsen/logrus"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// requiredCVOOverrides are the cluster version operator overrides that the
+// installer always sets.
+var requiredCVOOverrides = []configv1.ComponentOverride{}
+
+// mergeCVOOverrides returns the required overrides followed by the user
+// overrides. User overrides for a component that already has an override are
+// dropped, so required overrides always win.
+func mergeCVOOverrides(required []configv1.ComponentOverride, user []types.ClusterVersionOverride) []configv1.ComponentOverride {
+	type component struct {
+		group, kind, namespace, name string
+	}
+	seen := map[component]bool{}
+	merged := make([]configv1.ComponentOverride, 0, len(required)+len(user))
+	for _, o := range required {
+		seen[component{o.Group, o.Kind, o.Namespace, o.Name}] = true
+		merged = append(merged, o)
+	}
+	for _, o := range user {
+		c := component{o.Group, o.Kind, o.Namespace, o.Name}
+		if seen[c] {
+			logrus.Warnf("Ignoring cluster version override for %s %s/%s which is managed by the installer", o.Kind, o.Namespace, o.Name)
+			continue
+		}
+		seen[c] = true
+		merged = append(merged, configv1.ComponentOverride{
+			Kind:      o.Kind,
+			Group:     o.Group,
+			Namespace: o.Namespace,
+			Name:      o.Name,
+			Unmanaged: o.Unmanaged,
+		})
+	}
+	return merged
+}
@@ -0,0 +1,138 @@
+package manifests
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset/templates/content"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestMergeCVOOverrides(t *testing.T) {
+	required := []configv1.ComponentOverride{
+		{
+			Kind:      "Deployment",
+			Group:     "apps",
+			Namespace: "openshift-installer",
+			Name:      "installer-operator",
+			Unmanaged: true,
+		},
+	}
+	cases := []struct {
+		name     string
+		user     []types.ClusterVersionOverride
+		expected []configv1.ComponentOverride
+	}{
+		{
+			name:     "empty",
+			expected: required,
+		},
+		{
+			name: "new entry",
+			user: []types.ClusterVersionOverride{
+				{
+					Kind:      "Deployment",
+					Group:     "apps",
+					Namespace: "openshift-test",
+					Name:      "test-operator",
+					Unmanaged: true,
+				},
+			},
+			expected: []configv1.ComponentOverride{
+				required[0],
+				{
+					Kind:      "Deployment",
+					Group:     "apps",
+					Namespace: "openshift-test",
+					Name:      "test-operator",
+					Unmanaged: true,
+				},
+			},
+		},
+		{
+			name: "collides with installer entry",
+			user: []types.ClusterVersionOverride{
+				{
+					Kind:      "Deployment",
+					Group:     "apps",
+					Namespace: "openshift-installer",
+					Name:      "installer-operator",
+					Unmanaged: false,
+				},
+			},
+			expected: required,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, mergeCVOOverrides(required, tc.user))
+		})
+	}
+}
+
+func TestCVOOverridesTemplate(t *testing.T) {
+	tmpl, err := content.GetBootkubeTemplate("cvo-overrides.yaml.template")
+	if !assert.NoError(t, err, "unexpected error reading template") {
+		return
+	}
+	cases := []struct {
+		name      string
+		overrides []configv1.ComponentOverride
+		expected  string
+	}{
+		{
+			name: "no overrides",
+			expected: `apiVersion: config.openshift.io/v1
+kind: ClusterVersion
+metadata:
+  namespace: openshift-cluster-version
+  name: version
+spec:
+  upstream: https://api.openshift.com/api/upgrades_info/v1/graph
+  channel: stable-4.3
+  clusterID: test-cluster-id
+`,
+		},
+		{
+			name: "overrides",
+			overrides: []configv1.ComponentOverride{
+				{
+					Kind:      "Deployment",
+					Group:     "apps",
+					Namespace: "openshift-test",
+					Name:      "test-operator",
+					Unmanaged: true,
+				},
+			},
+			expected: `apiVersion: config.openshift.io/v1
+kind: ClusterVersion
+metadata:
+  namespace: openshift-cluster-version
+  name: version
+spec:
+  upstream: https://api.openshift.com/api/upgrades_info/v1/graph
+  channel: stable-4.3
+  clusterID: test-cluster-id
+  overrides:
+  - group: apps
+    kind: Deployment
+    name: test-operator
+    namespace: openshift-test
+    unmanaged: true
+`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			actual, err := applyTemplateData(tmpl, &BootkubeTemplateData{
+				CVOClusterID: "test-cluster-id",
+				CVOOverrides: tc.overrides,
+			})
+			if assert.NoError(t, err, "unexpected error rendering template") {
+				assert.Equal(t, tc.expected, string(actual))
+			}
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package manifests
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	"github.com/pmezard/go-difflib/difflib"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// Differences describes the differences between two sets of manifests.
+type Differences struct {
+	// Added are the files only present in the second set.
+	Added []string
+	// Removed are the files only present in the first set.
+	Removed []string
+	// Modified are the files present in both sets with different contents.
+	Modified []string
+	// Diffs maps each modified file to a unified diff of its contents.
+	Diffs map[string]string
+}
+
+// Empty returns whether the two sets of manifests are identical.
+func (d *Differences) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Modified) == 0
+}
+
+// Diff compares two sets of manifests by filename, regardless of the order of
+// their files. The filenames in the result are sorted.
+func Diff(a, b *Manifests) (*Differences, error) {
+	return diffFiles(filesByName(a.FileList), filesByName(b.FileList), func(a, b []byte) bool {
+		return string(a) == string(b)
+	})
+}
+
+// diffFiles compares two sets of files keyed by filename, with equal deciding
+// whether the contents of a file are the same in both.
+func diffFiles(aFiles, bFiles map[string]*asset.File, equal func(a, b []byte) bool) (*Differences, error) {
+	diff := &Differences{
+		Added:    []string{},
+		Removed:  []string{},
+		Modified: []string{},
+		Diffs:    map[string]string{},
+	}
+	for name, af := range aFiles {
+		bf, ok := bFiles[name]
+		if !ok {
+			diff.Removed = append(diff.Removed, name)
+			continue
+		}
+		if equal(af.Data, bf.Data) {
+			continue
+		}
+		diff.Modified = append(diff.Modified, name)
+		text, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+			A:        splitLines(af.Data),
+			B:        splitLines(bf.Data),
+			FromFile: "a/" + name,
+			ToFile:   "b/" + name,
+			Context:  3,
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to diff %s", name)
+		}
+		diff.Diffs[name] = text
+	}
+	for name := range bFiles {
+		if _, ok := aFiles[name]; !ok {
+			diff.Added = append(diff.Added, name)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Modified)
+	return diff, nil
+}
+
+// ExpectedFilenameDifferences compares the names of the files of the
+// manifests, typically loaded from disk, with the names of the files they
+// are expected to have. The expected files are ExpectedFilenames and, when
+// KubeSysConfig holds the install-config the manifests were generated for,
+// the bootkube manifests that are generated for it, such as those of
+// in-cluster etcd members. It returns the expected files that are missing,
+// which indicate a partial or corrupted export, and the files that are not
+// expected. The latter include the other manifests only generated for some
+// install-configs and the user manifests, so they are not necessarily a
+// problem. Both are sorted.
+func (m *Manifests) ExpectedFilenameDifferences() (missing, extra []string, err error) {
+	ic, err := m.kubeSysInstallConfig()
+	if err != nil {
+		return nil, nil, err
+	}
+	expected, err := m.expectedFilenames(ic)
+	if err != nil {
+		return nil, nil, err
+	}
+	files := filesByName(m.FileList)
+	expectedNames := make(map[string]bool, len(expected))
+	missing = []string{}
+	for _, name := range expected {
+		expectedNames[name] = true
+		if _, ok := files[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	extra = []string{}
+	for name := range files {
+		if !expectedNames[name] {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(extra)
+	return missing, extra, nil
+}
+
+// splitLines splits the data into lines, keeping their line endings.
+func splitLines(data []byte) []string {
+	lines := strings.SplitAfter(string(data), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+func filesByName(files []*asset.File) map[string]*asset.File {
+	byName := make(map[string]*asset.File, len(files))
+	for _, f := range files {
+		byName[f.Filename] = f
+	}
+	return byName
+}
+
+// kubeSysInstallConfig returns the install-config held by KubeSysConfig, or
+// nil if there is none.
+func (m *Manifests) kubeSysInstallConfig() (*types.InstallConfig, error) {
+	if m.KubeSysConfig == nil {
+		return nil, nil
+	}
+	data, ok := m.KubeSysConfig.Data["install-config"]
+	if !ok {
+		return nil, nil
+	}
+	ic := &types.InstallConfig{}
+	if err := yaml.Unmarshal([]byte(data), ic); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal the install-config of kube-system/%s", m.KubeSysConfig.Metadata.Name)
+	}
+	return ic, nil
+}
@@ -0,0 +1,194 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestDiff(t *testing.T) {
+	base := func() *Manifests {
+		return &Manifests{
+			FileList: []*asset.File{
+				{Filename: "manifests/a.yaml", Data: []byte("kind: A\nname: a\n")},
+				{Filename: "manifests/b.yaml", Data: []byte("kind: B\nname: b\n")},
+			},
+		}
+	}
+	cases := []struct {
+		name     string
+		modify   func(m *Manifests)
+		expected *Differences
+	}{
+		{
+			name: "identical in a different order",
+			modify: func(m *Manifests) {
+				m.FileList[0], m.FileList[1] = m.FileList[1], m.FileList[0]
+			},
+			expected: &Differences{
+				Added:    []string{},
+				Removed:  []string{},
+				Modified: []string{},
+				Diffs:    map[string]string{},
+			},
+		},
+		{
+			name: "added",
+			modify: func(m *Manifests) {
+				m.FileList = append(m.FileList, &asset.File{Filename: "manifests/c.yaml", Data: []byte("kind: C\n")})
+			},
+			expected: &Differences{
+				Added:    []string{"manifests/c.yaml"},
+				Removed:  []string{},
+				Modified: []string{},
+				Diffs:    map[string]string{},
+			},
+		},
+		{
+			name: "removed",
+			modify: func(m *Manifests) {
+				m.FileList = m.FileList[1:]
+			},
+			expected: &Differences{
+				Added:    []string{},
+				Removed:  []string{"manifests/a.yaml"},
+				Modified: []string{},
+				Diffs:    map[string]string{},
+			},
+		},
+		{
+			name: "modified",
+			modify: func(m *Manifests) {
+				m.FileList[1].Data = []byte("kind: B\nname: changed\n")
+			},
+			expected: &Differences{
+				Added:    []string{},
+				Removed:  []string{},
+				Modified: []string{"manifests/b.yaml"},
+				Diffs: map[string]string{
+					"manifests/b.yaml": `--- a/manifests/b.yaml
++++ b/manifests/b.yaml
+@@ -1,2 +1,2 @@
+ kind: B
+-name: b
++name: changed
+`,
+				},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			b := base()
+			tc.modify(b)
+			diff, err := Diff(base(), b)
+			if assert.NoError(t, err, "unexpected error") {
+				assert.Equal(t, tc.expected, diff)
+				assert.Equal(t, tc.name == "identical in a different order", diff.Empty())
+			}
+		})
+	}
+}
+
+func TestManifestsExpectedFilenameDifferences(t *testing.T) {
+	m := &Manifests{}
+	expected, err := m.ExpectedFilenames()
+	if err != nil {
+		t.Fatal(err)
+	}
+	complete := func() []*asset.File {
+		files := make([]*asset.File, len(expected))
+		for i, name := range expected {
+			files[i] = &asset.File{Filename: name, Data: []byte(name)}
+		}
+		return files
+	}
+
+	cases := []struct {
+		name            string
+		files           func() []*asset.File
+		expectedMissing []string
+		expectedExtra   []string
+	}{
+		{
+			name:            "complete",
+			files:           complete,
+			expectedMissing: []string{},
+			expectedExtra:   []string{},
+		},
+		{
+			name: "missing",
+			files: func() []*asset.File {
+				files := []*asset.File{}
+				for _, f := range complete() {
+					if f.Filename != kubeSysConfigPath && f.Filename != checksumsFilename {
+						files = append(files, f)
+					}
+				}
+				return files
+			},
+			expectedMissing: []string{kubeSysConfigPath, checksumsFilename},
+			expectedExtra:   []string{},
+		},
+		{
+			name: "extra",
+			files: func() []*asset.File {
+				return append(complete(), &asset.File{Filename: "manifests/99-user.yaml", Data: []byte("user")})
+			},
+			expectedMissing: []string{},
+			expectedExtra:   []string{"manifests/99-user.yaml"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			loaded := &Manifests{FileList: tc.files()}
+			missing, extra, err := loaded.ExpectedFilenameDifferences()
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expectedMissing, missing, "unexpected missing files")
+				assert.Equal(t, tc.expectedExtra, extra, "unexpected extra files")
+			}
+		})
+	}
+
+	// The install-config of the generated manifests tells which of the
+	// in-cluster etcd and conditional manifests are expected.
+	ic := testInstallConfig()
+	ic.EtcdBackup = &types.EtcdBackup{Schedule: "0 0 * * *", Image: "quay.io/openshift/etcd-backup:latest"}
+	generateTestAsset(t, ic, m)
+	missing, extra, err := m.ExpectedFilenameDifferences()
+	if assert.NoError(t, err) {
+		assert.Empty(t, missing)
+		for _, name := range []string{"etcd-service.yaml", "etcd-backup-cronjob.yaml"} {
+			assert.NotContains(t, extra, filepath.Join(manifestDir, name))
+		}
+	}
+
+	partial := &Manifests{KubeSysConfig: m.KubeSysConfig}
+	for _, f := range m.FileList {
+		if name := filepath.Base(f.Filename); name != "etcd-service.yaml" && name != "etcd-backup-cronjob.yaml" {
+			partial.FileList = append(partial.FileList, f)
+		}
+	}
+	missing, _, err = partial.ExpectedFilenameDifferences()
+	if assert.NoError(t, err) {
+		assert.Equal(t, []string{filepath.Join(manifestDir, "etcd-backup-cronjob.yaml"), filepath.Join(manifestDir, "etcd-service.yaml")}, missing)
+	}
+
+	// No in-cluster etcd manifest is expected for an external etcd.
+	_, externalBundle, _ := generateEtcdCerts(t)
+	ic = testInstallConfig()
+	ic.ExternalEtcd = &types.ExternalEtcd{
+		Hostnames: []string{"etcd-a", "etcd-b", "etcd-c"},
+		CABundle:  string(externalBundle.Cert()),
+	}
+	external := &Manifests{}
+	generateTestAsset(t, ic, external)
+	missing, _, err = external.ExpectedFilenameDifferences()
+	if assert.NoError(t, err) {
+		assert.Empty(t, missing)
+	}
+}
@@ -0,0 +1,52 @@
+//go:build go1.16
+// +build go1.16
+
+package manifests
+
+import (
+	"embed"
+	"io/fs"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/store/fetchers"
+)
+
+//go:embed testdata/embedded
+var embeddedManifests embed.FS
+
+func TestManifestsLoadEmbedded(t *testing.T) {
+	fsys, err := fs.Sub(embeddedManifests, "testdata/embedded")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []*asset.File{}
+	for _, name := range []string{"cluster-config.yaml", "etcd-namespace.yaml", "etcd-service.yaml"} {
+		data, err := ioutil.ReadFile(filepath.Join("testdata", "embedded", manifestDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		expected = append(expected, &asset.File{Filename: filepath.Join(manifestDir, name), Data: data})
+	}
+
+	m := &Manifests{}
+	found, err := m.Load(fetchers.NewFSFileFetcher(fsys))
+	if !assert.NoError(t, err, "unexpected error from Load") {
+		return
+	}
+	assert.True(t, found, "manifests not found")
+	assert.Equal(t, configMap("kube-system", "cluster-config-v1", genericData{
+		"install-config": "test-install-config",
+	}), m.KubeSysConfig, "unexpected KubeSysConfig")
+	assert.Equal(t, expected, m.FileList, "unexpected FileList")
+
+	// The embedded manifests load the same on every call.
+	again := &Manifests{}
+	if _, err := again.Load(fetchers.NewFSFileFetcher(fsys)); assert.NoError(t, err, "unexpected error from Load") {
+		assert.Equal(t, m.FileList, again.FileList, "the manifests did not load deterministically")
+	}
+}
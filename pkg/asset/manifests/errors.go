@@ -0,0 +1,61 @@
+package manifests
+
+// The errors below do not implement the Cause method of github.com/pkg/errors,
+// so that errors.Cause returns them, rather than what they wrap, when they are
+// wrapped with errors.Wrap. Their Unwrap method serves the standard library.
+
+// ManifestGenerationError is returned when a manifest cannot be rendered
+// from the template of a bootkube asset.
+type ManifestGenerationError struct {
+	// Asset is the name of the asset whose template failed to render.
+	Asset string
+	// Filename is the name of the manifest that was being rendered.
+	Filename string
+	// Err is the cause of the failure.
+	Err error
+}
+
+func (e *ManifestGenerationError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the cause of the failure.
+func (e *ManifestGenerationError) Unwrap() error {
+	return e.Err
+}
+
+// TLSConsistencyError is returned when a certificate does not chain to the
+// CA bundle it is distributed with.
+type TLSConsistencyError struct {
+	// Certificate is the name of the certificate that failed to verify.
+	Certificate string
+	// CABundle is the name of the CA bundle it was verified against.
+	CABundle string
+	// Err is the cause of the failure.
+	Err error
+}
+
+func (e *TLSConsistencyError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the cause of the failure.
+func (e *TLSConsistencyError) Unwrap() error {
+	return e.Err
+}
+
+// RedactionError is returned when the install-config stored in the cluster
+// cannot be redacted.
+type RedactionError struct {
+	// Err is the cause of the failure.
+	Err error
+}
+
+func (e *RedactionError) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap returns the cause of the failure.
+func (e *RedactionError) Unwrap() error {
+	return e.Err
+}
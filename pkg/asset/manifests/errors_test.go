@@ -0,0 +1,69 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content"
+	"github.com/openshift/installer/pkg/asset/templates/content/bootkube"
+)
+
+// generateWrapped generates the manifests, wrapping the error the way the
+// asset store does.
+func generateWrapped(m *Manifests, parents asset.Parents) error {
+	if err := m.Generate(parents); err != nil {
+		return errors.Wrapf(err, "failed to generate asset %q", m.Name())
+	}
+	return nil
+}
+
+func TestManifestsGenerateTemplateError(t *testing.T) {
+	ic := testInstallConfig()
+	ic.ClusterMonitoring = true
+	m := &Manifests{}
+	parents := generateTestParents(t, ic, m)
+	parents.Add(&bootkube.EtcdServiceMonitor{
+		FileList: []*asset.File{{
+			Filename: filepath.Join(content.TemplateDir, "etcd-service-monitor.yaml.template"),
+			Data:     []byte("{{ .Missing }}"),
+		}},
+	})
+
+	err := generateWrapped(m, parents)
+	generationErr, ok := errors.Cause(err).(*ManifestGenerationError)
+	if assert.True(t, ok, "unexpected error: %v", err) {
+		assert.Equal(t, (&bootkube.EtcdServiceMonitor{}).Name(), generationErr.Asset)
+		assert.Equal(t, filepath.Join(manifestDir, "etcd-service-monitor.yaml"), generationErr.Filename)
+		assert.Contains(t, err.Error(), "failed to render manifests/etcd-service-monitor.yaml")
+	}
+}
+
+func TestManifestsGenerateTLSConsistencyError(t *testing.T) {
+	m := &Manifests{}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	_, _, otherClient := generateEtcdCerts(t)
+	parents.Add(otherClient)
+
+	err := generateWrapped(m, parents)
+	tlsErr, ok := errors.Cause(err).(*TLSConsistencyError)
+	if assert.True(t, ok, "unexpected error: %v", err) {
+		assert.Equal(t, "etcd-client", tlsErr.Certificate)
+		assert.Equal(t, "etcd-ca-bundle", tlsErr.CABundle)
+		assert.Contains(t, err.Error(), "etcd-client certificate does not chain to etcd-ca-bundle")
+	}
+}
+
+func TestManifestsGenerateRedactionError(t *testing.T) {
+	m := &Manifests{RedactedFields: []string{"metadata.missing"}}
+	parents := generateTestParents(t, testInstallConfig(), m)
+
+	err := generateWrapped(m, parents)
+	_, ok := errors.Cause(err).(*RedactionError)
+	if assert.True(t, ok, "unexpected error: %v", err) {
+		assert.Contains(t, err.Error(), "failed to redact install-config")
+	}
+}
@@ -0,0 +1,17 @@
+package manifests
+
+// inClusterEtcdManifests are the bootkube manifests that only apply to etcd
+// members running in the cluster, and are not generated when the cluster
+// uses an external etcd.
+var inClusterEtcdManifests = map[string]bool{
+	"etcd-backup-client-secret.yaml":            true,
+	"etcd-backup-cronjob.yaml":                  true,
+	"etcd-backup-role.yaml":                     true,
+	"etcd-backup-rolebinding.yaml":              true,
+	"etcd-backup-serviceaccount.yaml":           true,
+	"etcd-monitoring-client-secret.yaml":        true,
+	"etcd-monitoring-serving-ca-configmap.yaml": true,
+	"etcd-pod-disruption-budget.yaml":           true,
+	"etcd-service-monitor.yaml":                 true,
+	"etcd-service.yaml":                         true,
+}
@@ -0,0 +1,63 @@
+package manifests
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestManifestsExternalEtcd(t *testing.T) {
+	_, externalBundle, _ := generateEtcdCerts(t)
+	ic := testInstallConfig()
+	ic.ExternalEtcd = &types.ExternalEtcd{
+		Hostnames: []string{"etcd-a", "etcd-b", "etcd-c"},
+		CABundle:  string(externalBundle.Cert()),
+	}
+	ic.ClusterMonitoring = true
+	m := &Manifests{}
+	generateTestAsset(t, ic, m)
+	files := m.FileMap()
+
+	for name := range inClusterEtcdManifests {
+		_, ok := files[filepath.Join(manifestDir, name)]
+		assert.False(t, ok, "unexpected %s for an external etcd", name)
+	}
+
+	// There are no etcd metrics in the namespace to scrape.
+	namespace, ok := files[filepath.Join(manifestDir, "etcd-namespace.yaml")]
+	if assert.True(t, ok, "missing etcd namespace") {
+		assert.NotContains(t, string(namespace.Data), "openshift.io/cluster-monitoring")
+	}
+
+	endpoints, ok := files[filepath.Join(manifestDir, "etcd-host-service-endpoints.yaml")]
+	if assert.True(t, ok, "missing host-etcd endpoints") {
+		for _, hostname := range ic.ExternalEtcd.Hostnames {
+			assert.Contains(t, string(endpoints.Data), "hostname: "+hostname)
+		}
+		assert.NotContains(t, string(endpoints.Data), "hostname: etcd-0")
+	}
+
+	// The PEM is indented in the configmaps, so compare a line of it.
+	caLine := strings.Split(ic.ExternalEtcd.CABundle, "\n")[1]
+	for _, name := range []string{"etcd-ca-bundle-configmap.yaml", "etcd-serving-ca-configmap.yaml"} {
+		f, ok := files[filepath.Join(manifestDir, name)]
+		if assert.True(t, ok, "missing %s", name) {
+			assert.Contains(t, string(f.Data), caLine, "%s does not hold the external CA bundle", name)
+		}
+	}
+}
+
+func TestEtcdEndpointHostnamesExternal(t *testing.T) {
+	hostnames := []string{"etcd-a", "etcd-b"}
+	ic := testInstallConfig()
+	ic.ExternalEtcd = &types.ExternalEtcd{Hostnames: hostnames}
+
+	got := EtcdEndpointHostnames(ic)
+	assert.Equal(t, hostnames, got)
+	got[0] = "changed"
+	assert.Equal(t, "etcd-a", hostnames[0], "the install-config hostnames were modified")
+}
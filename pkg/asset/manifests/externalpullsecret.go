@@ -0,0 +1,25 @@
+package manifests
+
+import (
+	"github.com/pkg/errors"
+)
+
+// ExternalPullSecretReference references a pull secret managed outside the
+// installer, by the External Secrets Operator.
+type ExternalPullSecretReference struct {
+	// Name is the key of the pull secret in the secret store.
+	Name string
+	// Store is the name of the ClusterSecretStore holding the pull secret.
+	Store string
+}
+
+// validate returns an error if the reference is incomplete.
+func (r *ExternalPullSecretReference) validate() error {
+	if r.Name == "" {
+		return errors.New("the external pull secret name is required")
+	}
+	if r.Store == "" {
+		return errors.New("the external pull secret store is required")
+	}
+	return nil
+}
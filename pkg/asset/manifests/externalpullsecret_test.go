@@ -0,0 +1,101 @@
+package manifests
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+var pullSecretFilename = filepath.Join(manifestDir, "openshift-config-secret-pull-secret.yaml")
+
+func TestManifestsEmbeddedPullSecret(t *testing.T) {
+	ic := testInstallConfig()
+	m := &Manifests{}
+	generateTestAsset(t, ic, m)
+
+	var secret corev1.Secret
+	if err := yaml.Unmarshal(m.FileMap()[pullSecretFilename].Data, &secret); err != nil {
+		t.Fatalf("failed to unmarshal the pull secret: %v", err)
+	}
+	assert.Equal(t, "Secret", secret.Kind)
+	assert.Equal(t, "openshift-config", secret.Namespace)
+	assert.Equal(t, "pull-secret", secret.Name)
+	assert.Equal(t, corev1.SecretTypeDockerConfigJson, secret.Type)
+	assert.Equal(t, ic.PullSecret, string(secret.Data[corev1.DockerConfigJsonKey]))
+}
+
+func TestManifestsExternalPullSecret(t *testing.T) {
+	ic := testInstallConfig()
+	m := &Manifests{
+		ExternalPullSecret: &ExternalPullSecretReference{
+			Name:  "clusters/test-cluster/pull-secret",
+			Store: "vault",
+		},
+	}
+	generateTestAsset(t, ic, m)
+
+	data := m.FileMap()[pullSecretFilename].Data
+	assert.NotContains(t, string(data), base64.StdEncoding.EncodeToString([]byte(ic.PullSecret)), "the pull secret was embedded")
+	var actual map[string]interface{}
+	if err := yaml.Unmarshal(data, &actual); err != nil {
+		t.Fatalf("failed to unmarshal the pull secret: %v", err)
+	}
+	delete(actual["metadata"].(map[string]interface{}), "annotations")
+	assert.Equal(t, map[string]interface{}{
+		"apiVersion": "external-secrets.io/v1beta1",
+		"kind":       "ExternalSecret",
+		"metadata": map[string]interface{}{
+			"namespace": "openshift-config",
+			"name":      "pull-secret",
+		},
+		"spec": map[string]interface{}{
+			"secretStoreRef": map[string]interface{}{
+				"kind": "ClusterSecretStore",
+				"name": "vault",
+			},
+			"target": map[string]interface{}{
+				"name": "pull-secret",
+				"template": map[string]interface{}{
+					"type": "kubernetes.io/dockerconfigjson",
+				},
+			},
+			"dataFrom": []interface{}{
+				map[string]interface{}{
+					"extract": map[string]interface{}{
+						"key": "clusters/test-cluster/pull-secret",
+					},
+				},
+			},
+		},
+	}, actual)
+}
+
+func TestManifestsInvalidExternalPullSecret(t *testing.T) {
+	cases := []struct {
+		name          string
+		ref           ExternalPullSecretReference
+		expectedError string
+	}{
+		{
+			name:          "missing name",
+			ref:           ExternalPullSecretReference{Store: "vault"},
+			expectedError: "the external pull secret name is required",
+		},
+		{
+			name:          "missing store",
+			ref:           ExternalPullSecretReference{Name: "pull-secret"},
+			expectedError: "the external pull secret store is required",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Manifests{ExternalPullSecret: &tc.ref}
+			err := m.Generate(generateTestParents(t, testInstallConfig(), m))
+			assert.EqualError(t, err, tc.expectedError)
+		})
+	}
+}
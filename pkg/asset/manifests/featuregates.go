@@ -0,0 +1,22 @@
+package manifests
+
+import (
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// FeatureGated is implemented by the bootkube template assets, registered
+// with RegisterBootkubeTemplate, whose manifests are only generated when a
+// feature gate is enabled in the install-config, such as those of
+// TechPreview-only objects.
+type FeatureGated interface {
+	// RequiredFeatureGate returns the name of the feature gate.
+	RequiredFeatureGate() string
+}
+
+// featureGateEnabled returns whether the feature gate the asset requires, if
+// any, is enabled in the install-config.
+func featureGateEnabled(a asset.Asset, config *types.InstallConfig) bool {
+	gated, ok := a.(FeatureGated)
+	return !ok || config.FeatureGateEnabled(gated.RequiredFeatureGate())
+}
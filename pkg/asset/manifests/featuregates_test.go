@@ -0,0 +1,80 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// testFeatureGatedTemplate is a bootkube template asset that requires the
+// TechPreview feature gate.
+type testFeatureGatedTemplate struct {
+	FileList []*asset.File
+}
+
+var _ FeatureGated = (*testFeatureGatedTemplate)(nil)
+
+func (t *testFeatureGatedTemplate) Dependencies() []asset.Asset { return []asset.Asset{} }
+
+func (t *testFeatureGatedTemplate) Name() string { return "TestFeatureGatedTemplate" }
+
+func (t *testFeatureGatedTemplate) Generate(asset.Parents) error {
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join("templates", "test-tech-preview.yaml.template"),
+			Data:     []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: tech-preview\n  namespace: openshift-config\ndata:\n  clusterID: {{ .CVOClusterID }}\n"),
+		},
+	}
+	return nil
+}
+
+func (t *testFeatureGatedTemplate) Files() []*asset.File { return t.FileList }
+
+func (t *testFeatureGatedTemplate) Load(asset.FileFetcher) (bool, error) { return false, nil }
+
+func (t *testFeatureGatedTemplate) RequiredFeatureGate() string { return "TechPreview" }
+
+func TestManifestsFeatureGates(t *testing.T) {
+	defer func(registered []func() asset.WritableAsset) {
+		registeredBootkubeTemplates = registered
+	}(registeredBootkubeTemplates)
+	RegisterBootkubeTemplate(func() asset.WritableAsset { return &testFeatureGatedTemplate{} })
+
+	filename := filepath.Join(manifestDir, "test-tech-preview.yaml")
+	cases := []struct {
+		name            string
+		featureGates    []string
+		expectedPresent bool
+	}{
+		{
+			name: "no feature gates",
+		},
+		{
+			name:         "other feature gate",
+			featureGates: []string{"CustomNoUpgrade"},
+		},
+		{
+			name:            "enabled",
+			featureGates:    []string{"CustomNoUpgrade", "TechPreview"},
+			expectedPresent: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := testInstallConfig()
+			ic.FeatureGates = tc.featureGates
+			m := &Manifests{}
+			generateTestAsset(t, ic, m)
+			_, ok := m.FileMap()[filename]
+			assert.Equal(t, tc.expectedPresent, ok, "unexpected presence of %s", filename)
+		})
+	}
+
+	expected, err := (&Manifests{}).ExpectedFilenames()
+	if assert.NoError(t, err) {
+		assert.NotContains(t, expected, filename)
+	}
+}
@@ -0,0 +1,80 @@
+package manifests
+
+import (
+	"os"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+const (
+	// manifestFileMode is the mode of the manifest files.
+	manifestFileMode os.FileMode = 0644
+
+	// secretFileMode is the mode of the manifest files holding a Secret,
+	// which must not be readable by other users.
+	secretFileMode os.FileMode = 0600
+)
+
+var (
+	_ asset.FileModer = (*Manifests)(nil)
+	_ asset.FileModer = (*Openshift)(nil)
+)
+
+// FileModes returns the modes of the manifest files that hold a Secret,
+// which are written readable only by their owner.
+func (m *Manifests) FileModes() map[string]os.FileMode {
+	return fileModes(m.FileList)
+}
+
+// FileModes returns the modes of the OpenShift manifest files that hold a
+// Secret, which are written readable only by their owner.
+func (o *Openshift) FileModes() map[string]os.FileMode {
+	return fileModes(o.FileList)
+}
+
+// fileModes returns the modes of the files that are not written with the
+// default mode, by file name.
+func fileModes(files []*asset.File) map[string]os.FileMode {
+	modes := map[string]os.FileMode{}
+	for _, f := range files {
+		if mode := fileMode(f); mode != manifestFileMode {
+			modes[f.Filename] = mode
+		}
+	}
+	return modes
+}
+
+// fileMode returns the mode the manifest file should be written with.
+func fileMode(f *asset.File) os.FileMode {
+	if holdsSecret(f.Data) {
+		return secretFileMode
+	}
+	return manifestFileMode
+}
+
+// holdsSecret returns whether any of the YAML documents, or any item of a v1
+// List, is a Secret.
+func holdsSecret(data []byte) bool {
+	for _, doc := range documentSeparator.Split(string(data), -1) {
+		var object struct {
+			Kind  string `json:"kind"`
+			Items []struct {
+				Kind string `json:"kind"`
+			} `json:"items"`
+		}
+		if err := yaml.Unmarshal([]byte(doc), &object); err != nil {
+			continue
+		}
+		if object.Kind == "Secret" {
+			return true
+		}
+		for _, item := range object.Items {
+			if item.Kind == "Secret" {
+				return true
+			}
+		}
+	}
+	return false
+}
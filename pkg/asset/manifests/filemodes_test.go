@@ -0,0 +1,69 @@
+package manifests
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestFileMode(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     string
+		expected os.FileMode
+	}{
+		{
+			name:     "secret",
+			data:     "apiVersion: v1\nkind: Secret\nmetadata:\n  name: test\ndata:\n  key: dmFsdWU=\n",
+			expected: 0600,
+		},
+		{
+			name:     "config map",
+			data:     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\ndata:\n  key: value\n",
+			expected: 0644,
+		},
+		{
+			name:     "sealed secret",
+			data:     "apiVersion: bitnami.com/v1alpha1\nkind: SealedSecret\nmetadata:\n  name: test\n",
+			expected: 0644,
+		},
+		{
+			name:     "secret in a later document",
+			data:     "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: test\n",
+			expected: 0600,
+		},
+		{
+			name:     "secret in a JSON list",
+			data:     `{"apiVersion": "v1", "kind": "List", "items": [{"apiVersion": "v1", "kind": "Secret", "metadata": {"name": "test"}}]}`,
+			expected: 0600,
+		},
+		{
+			name:     "not YAML",
+			data:     "not: [valid\n",
+			expected: 0644,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, fileMode(&asset.File{Filename: "manifests/test.yaml", Data: []byte(tc.data)}))
+		})
+	}
+}
+
+func TestManifestsFileModes(t *testing.T) {
+	m := &Manifests{}
+	if err := m.Generate(generateTestParents(t, testInstallConfig(), m)); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+
+	modes := m.FileModes()
+	assert.Equal(t, os.FileMode(0600), modes["manifests/kube-cloud-config.yaml"], "unexpected mode for the cloud config secret")
+	_, ok := modes["manifests/cluster-config.yaml"]
+	assert.False(t, ok, "unexpected mode for the cluster config")
+	for filename, mode := range modes {
+		assert.Equal(t, os.FileMode(0600), mode, "unexpected mode for %s", filename)
+	}
+}
@@ -0,0 +1,60 @@
+package manifests
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// KindPriority returns a FilePriority that ranks each file by the priority
+// of the kinds of its objects, such as {"Namespace": 0,
+// "CustomResourceDefinition": 1}. A file holding several objects has the
+// lowest priority of their kinds. Files holding no kind of the map, and
+// files that do not parse, rank after all of those that do.
+func KindPriority(priorities map[string]int) func(*asset.File) int {
+	unlisted := 0
+	for _, priority := range priorities {
+		if priority >= unlisted {
+			unlisted = priority + 1
+		}
+	}
+	return func(f *asset.File) int {
+		rank := unlisted
+		for _, doc := range documentSeparator.Split(string(f.Data), -1) {
+			if strings.TrimSpace(doc) == "" {
+				continue
+			}
+			var typeMeta metav1.TypeMeta
+			if err := yaml.Unmarshal([]byte(doc), &typeMeta); err != nil {
+				continue
+			}
+			if priority, ok := priorities[typeMeta.Kind]; ok && priority < rank {
+				rank = priority
+			}
+		}
+		return rank
+	}
+}
+
+// sortFiles sorts the files by FilePriority and then by file name, or only
+// by file name as asset.SortFiles does when FilePriority is unset.
+func (m *Manifests) sortFiles(files []*asset.File) {
+	if m.FilePriority == nil {
+		asset.SortFiles(files)
+		return
+	}
+	priorities := make(map[*asset.File]int, len(files))
+	for _, f := range files {
+		priorities[f] = m.FilePriority(f)
+	}
+	sort.Slice(files, func(i, j int) bool {
+		if pi, pj := priorities[files[i]], priorities[files[j]]; pi != pj {
+			return pi < pj
+		}
+		return files[i].Filename < files[j].Filename
+	})
+}
@@ -0,0 +1,85 @@
+package manifests
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestKindPriority(t *testing.T) {
+	priority := KindPriority(map[string]int{"Namespace": 0, "CustomResourceDefinition": 1})
+	cases := []struct {
+		name     string
+		data     string
+		expected int
+	}{
+		{
+			name:     "namespace",
+			data:     "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: a\n",
+			expected: 0,
+		},
+		{
+			name:     "custom resource definition",
+			data:     "apiVersion: apiextensions.k8s.io/v1beta1\nkind: CustomResourceDefinition\n",
+			expected: 1,
+		},
+		{
+			name:     "unlisted kind",
+			data:     "apiVersion: v1\nkind: ConfigMap\n",
+			expected: 2,
+		},
+		{
+			name:     "lowest of several objects",
+			data:     "apiVersion: v1\nkind: ConfigMap\n---\napiVersion: v1\nkind: Namespace\n",
+			expected: 0,
+		},
+		{
+			name:     "invalid",
+			data:     "not: [valid\n",
+			expected: 2,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, priority(&asset.File{Filename: "manifests/test.yaml", Data: []byte(tc.data)}))
+		})
+	}
+}
+
+func TestManifestsFilePriority(t *testing.T) {
+	plain := &Manifests{}
+	generateTestAsset(t, testInstallConfig(), plain)
+	filenames := func(files []*asset.File) []string {
+		names := make([]string, len(files))
+		for i, f := range files {
+			names[i] = f.Filename
+		}
+		return names
+	}
+	assert.True(t, sort.StringsAreSorted(filenames(plain.FileList)), "the files are not sorted by name by default")
+
+	m := &Manifests{FilePriority: KindPriority(map[string]int{"Namespace": 0})}
+	generateTestAsset(t, testInstallConfig(), m)
+	assert.ElementsMatch(t, filenames(plain.FileList), filenames(m.FileList), "unexpected files")
+
+	namespaces, others := []string{}, []string{}
+	for _, f := range m.FileList {
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(f.Data, &typeMeta); err == nil && typeMeta.Kind == "Namespace" {
+			if !assert.Empty(t, others, "%s follows files of a lower priority", f.Filename) {
+				return
+			}
+			namespaces = append(namespaces, f.Filename)
+			continue
+		}
+		others = append(others, f.Filename)
+	}
+	assert.Contains(t, namespaces, "manifests/etcd-namespace.yaml")
+	assert.True(t, sort.StringsAreSorted(namespaces), "the namespaces are not sorted by name")
+	assert.True(t, sort.StringsAreSorted(others), "the other files are not sorted by name")
+}
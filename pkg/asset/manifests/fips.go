@@ -0,0 +1,25 @@
+package manifests
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset/tls"
+)
+
+// fipsCertificate names a certificate that must be FIPS compliant.
+type fipsCertificate struct {
+	name string
+	cert []byte
+}
+
+// verifyFIPSCertificates returns an error for the first certificate that uses
+// an algorithm which is not FIPS approved, so that a cluster requesting FIPS
+// is never handed non-compliant TLS material.
+func verifyFIPSCertificates(certs []fipsCertificate) error {
+	for _, c := range certs {
+		if err := tls.VerifyFIPSCertificate(c.cert); err != nil {
+			return errors.Wrapf(err, "%s certificate is not FIPS compliant", c.name)
+		}
+	}
+	return nil
+}
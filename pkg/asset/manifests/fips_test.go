@@ -0,0 +1,95 @@
+package manifests
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/tls"
+)
+
+// rsa1024EtcdClientCertKey returns an etcd client cert with a key that is too
+// small to be FIPS compliant, signed by the etcd signer in the parents.
+func rsa1024EtcdClientCertKey(t *testing.T, parents asset.Parents) *tls.EtcdSignerClientCertKey {
+	signer := &tls.EtcdSignerCertKey{}
+	parents.Get(signer)
+	caKey, err := tls.PemToPrivateKey(signer.Key())
+	if err != nil {
+		t.Fatalf("failed to parse etcd signer key: %v", err)
+	}
+	caCert, err := tls.PemToCertificate(signer.Cert())
+	if err != nil {
+		t.Fatalf("failed to parse etcd signer cert: %v", err)
+	}
+
+	key, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatalf("failed to generate private key: %v", err)
+	}
+	cfg := &tls.CertCfg{
+		Subject:      pkix.Name{CommonName: "etcd", OrganizationalUnit: []string{"etcd"}},
+		KeyUsages:    x509.KeyUsageKeyEncipherment,
+		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		Validity:     tls.ValidityOneDay,
+	}
+	cert, err := tls.SignedCertificate(cfg, &x509.CertificateRequest{Subject: cfg.Subject}, key, caCert, caKey)
+	if err != nil {
+		t.Fatalf("failed to sign certificate: %v", err)
+	}
+	keyPEM, err := tls.PrivateKeyToPem(key)
+	if err != nil {
+		t.Fatalf("failed to encode private key: %v", err)
+	}
+
+	client := &tls.EtcdSignerClientCertKey{}
+	client.CertRaw = tls.CertToPem(cert)
+	client.KeyRaw = keyPEM
+	return client
+}
+
+func TestManifestsGenerateFIPS(t *testing.T) {
+	cases := []struct {
+		name          string
+		fips          bool
+		rsa1024       bool
+		expectedError string
+	}{
+		{
+			name: "compliant",
+			fips: true,
+		},
+		{
+			name:    "non-compliant without FIPS",
+			rsa1024: true,
+		},
+		{
+			name:          "non-compliant with FIPS",
+			fips:          true,
+			rsa1024:       true,
+			expectedError: "etcd-client certificate is not FIPS compliant: 1024-bit RSA keys are not FIPS approved",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := testInstallConfig()
+			ic.FIPS = tc.fips
+			m := &Manifests{}
+			parents := generateTestParents(t, ic, m)
+			if tc.rsa1024 {
+				parents.Add(rsa1024EtcdClientCertKey(t, parents))
+			}
+
+			err := m.Generate(parents)
+			if tc.expectedError == "" {
+				assert.NoError(t, err, "unexpected error")
+			} else if assert.Error(t, err, "expected an error") {
+				assert.Contains(t, err.Error(), tc.expectedError)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package manifests
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// OutputFormat is the serialization of the generated manifests.
+type OutputFormat string
+
+const (
+	// FormatYAML writes the manifests as YAML. It is the default.
+	FormatYAML OutputFormat = "yaml"
+	// FormatJSON writes the manifests as indented JSON. Files holding
+	// several YAML documents are written as a v1 List of the documents.
+	FormatJSON OutputFormat = "json"
+)
+
+// documentSeparator matches the lines separating YAML documents.
+var documentSeparator = regexp.MustCompile(`(?m)^---[ \t]*$`)
+
+// convertFiles returns the files serialized in the given format. The files
+// are copied rather than modified, since they may be shared with the assets
+// that generated them.
+func convertFiles(files []*asset.File, format OutputFormat) ([]*asset.File, error) {
+	switch format {
+	case "", FormatYAML:
+		return files, nil
+	case FormatJSON:
+	default:
+		return nil, errors.Errorf("unsupported output format %q", format)
+	}
+
+	converted := make([]*asset.File, len(files))
+	for i, f := range files {
+		data, err := yamlToIndentedJSON(f.Data)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to convert %s to JSON", f.Filename)
+		}
+		converted[i] = &asset.File{
+			Filename: f.Filename,
+			Data:     data,
+		}
+	}
+	return converted, nil
+}
+
+// yamlToIndentedJSON converts a YAML stream to indented JSON.
+func yamlToIndentedJSON(data []byte) ([]byte, error) {
+	docs := []json.RawMessage{}
+	for _, doc := range documentSeparator.Split(string(data), -1) {
+		j, err := yaml.YAMLToJSON([]byte(doc))
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Equal(j, []byte("null")) {
+			continue
+		}
+		docs = append(docs, j)
+	}
+
+	var value interface{}
+	switch len(docs) {
+	case 0:
+		value = map[string]interface{}{}
+	case 1:
+		value = docs[0]
+	default:
+		value = map[string]interface{}{
+			"apiVersion": "v1",
+			"kind":       "List",
+			"items":      docs,
+		}
+	}
+	out, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(out, '\n'), nil
+}
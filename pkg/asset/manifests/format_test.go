@@ -0,0 +1,75 @@
+package manifests
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+)
+
+// yamlDocuments returns the values of the documents in a YAML stream.
+func yamlDocuments(t *testing.T, data []byte) []interface{} {
+	docs := []interface{}{}
+	for _, doc := range documentSeparator.Split(string(data), -1) {
+		var value interface{}
+		if err := yaml.Unmarshal([]byte(doc), &value); err != nil {
+			t.Fatalf("failed to unmarshal YAML document: %v", err)
+		}
+		if value != nil {
+			docs = append(docs, value)
+		}
+	}
+	return docs
+}
+
+func TestManifestsGenerateJSON(t *testing.T) {
+	ic := testInstallConfig()
+	yamlManifests := &Manifests{}
+	parents := generateTestParents(t, ic, yamlManifests)
+	if err := yamlManifests.Generate(parents); err != nil {
+		t.Fatalf("failed to generate YAML manifests: %v", err)
+	}
+	jsonManifests := &Manifests{Format: FormatJSON}
+	if err := jsonManifests.Generate(parents); err != nil {
+		t.Fatalf("failed to generate JSON manifests: %v", err)
+	}
+
+	if !assert.Len(t, jsonManifests.FileList, len(yamlManifests.FileList), "unexpected number of files") {
+		return
+	}
+	for i, f := range jsonManifests.FileList {
+		original := yamlManifests.FileList[i]
+		if !assert.Equal(t, original.Filename, f.Filename, "unexpected filename") {
+			continue
+		}
+		if !assert.True(t, json.Valid(f.Data), "%s is not valid JSON", f.Filename) {
+			continue
+		}
+		var actual interface{}
+		if err := json.Unmarshal(f.Data, &actual); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", f.Filename, err)
+		}
+
+		if f.Filename == checksumsFilename {
+			// the checksums cover the JSON data
+			continue
+		}
+		docs := yamlDocuments(t, original.Data)
+		if len(docs) == 1 {
+			assert.Equal(t, docs[0], actual, "%s does not match the YAML content", f.Filename)
+		} else {
+			assert.Equal(t, map[string]interface{}{
+				"apiVersion": "v1",
+				"kind":       "List",
+				"items":      docs,
+			}, actual, "%s does not match the YAML content", f.Filename)
+		}
+	}
+	assert.Equal(t, yamlManifests.KubeSysConfig, jsonManifests.KubeSysConfig, "unexpected KubeSysConfig")
+}
+
+func TestConvertFilesUnsupportedFormat(t *testing.T) {
+	_, err := convertFiles(nil, OutputFormat("toml"))
+	assert.EqualError(t, err, `unsupported output format "toml"`)
+}
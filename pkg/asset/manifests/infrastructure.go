@@ -74,7 +74,7 @@ func (i *Infrastructure) Generate(dependencies asset.Parents) error {
 			InfrastructureName:   clusterID.InfraID,
 			APIServerURL:         getAPIServerURL(installConfig.Config),
 			APIServerInternalURL: getInternalAPIServerURL(installConfig.Config),
-			EtcdDiscoveryDomain:  getEtcdDiscoveryDomain(installConfig.Config),
+			EtcdDiscoveryDomain:  installConfig.Config.EtcdDiscoveryDomain(),
 			PlatformStatus:       &configv1.PlatformStatus{},
 		},
 	}
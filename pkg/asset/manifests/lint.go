@@ -0,0 +1,127 @@
+package manifests
+
+import (
+	"reflect"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/installconfig/aws"
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/conversion"
+	"github.com/openshift/installer/pkg/types/defaults"
+	openstackvalidation "github.com/openshift/installer/pkg/types/openstack/validation"
+	"github.com/openshift/installer/pkg/types/validation"
+)
+
+// LintReport lists the problems Lint found with an install-config.
+type LintReport struct {
+	// Problems are the errors found, in the order they were found. The
+	// invalid fields of the install-config are each reported separately,
+	// and no manifests are generated for an invalid install-config.
+	Problems []error
+}
+
+// OK returns whether no problems were found.
+func (r *LintReport) OK() bool {
+	return len(r.Problems) == 0
+}
+
+// Lint checks that the install-config produces a consistent set of manifests
+// with the options of m. The install-config is upconverted, defaulted and
+// validated as it is when loaded from disk, and the manifests and all of
+// their dependencies are then generated in memory and checked with Validate
+// and against ExpectedFilenames. Platform lookups made by the dependencies
+// still take place, but nothing is written and m is left unchanged: the
+// generated manifests are discarded, so that the result cannot be mistaken
+// for manifests fit to install a cluster.
+func (m *Manifests) Lint(config *types.InstallConfig) *LintReport {
+	report := &LintReport{}
+	if config == nil {
+		report.Problems = append(report.Problems, errors.New("no install-config"))
+		return report
+	}
+
+	// The install-config is copied, as it is defaulted in place.
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		report.Problems = append(report.Problems, errors.Wrap(err, "failed to copy the install-config"))
+		return report
+	}
+	installConfig := &installconfig.InstallConfig{Config: &types.InstallConfig{}}
+	if err := yaml.Unmarshal(data, installConfig.Config); err != nil {
+		report.Problems = append(report.Problems, errors.Wrap(err, "failed to copy the install-config"))
+		return report
+	}
+	if err := conversion.ConvertInstallConfig(installConfig.Config); err != nil {
+		report.Problems = append(report.Problems, errors.Wrap(err, "failed to upconvert the install-config"))
+		return report
+	}
+	defaults.SetInstallConfigDefaults(installConfig.Config)
+	for _, err := range validation.ValidateInstallConfig(installConfig.Config, openstackvalidation.NewValidValuesFetcher()) {
+		report.Problems = append(report.Problems, errors.Wrap(err, "invalid install-config"))
+	}
+	if !report.OK() {
+		return report
+	}
+	if installConfig.Config.AWS != nil {
+		installConfig.AWS = aws.NewMetadata(installConfig.Config.Platform.AWS.Region, installConfig.Config.Platform.AWS.Subnets)
+	}
+
+	linted := m.DeepCopy()
+	linted.FileList, linted.KubeSysConfig = nil, nil
+	parents, err := generateInMemory(linted, installConfig)
+	if err != nil {
+		report.Problems = append(report.Problems, err)
+		return report
+	}
+	if err := linted.Generate(parents); err != nil {
+		report.Problems = append(report.Problems, errors.Wrapf(err, "failed to generate %s", linted.Name()))
+		return report
+	}
+	if err := linted.Validate(); err != nil {
+		report.Problems = append(report.Problems, errors.Wrap(err, "invalid manifests"))
+	}
+
+	missing, _, err := linted.ExpectedFilenameDifferences()
+	if err != nil {
+		report.Problems = append(report.Problems, err)
+		return report
+	}
+	for _, filename := range missing {
+		report.Problems = append(report.Problems, errors.Errorf("%s was not generated", filename))
+	}
+	return report
+}
+
+// generateInMemory generates all of the dependencies of the asset, using the
+// given InstallConfig asset in place of generating one, and returns them as
+// the parents of the asset.
+func generateInMemory(a asset.Asset, installConfig *installconfig.InstallConfig) (asset.Parents, error) {
+	generated := map[reflect.Type]asset.Asset{
+		reflect.TypeOf(installConfig): installConfig,
+	}
+	var generate func(a asset.Asset) (asset.Parents, error)
+	generate = func(a asset.Asset) (asset.Parents, error) {
+		parents := asset.Parents{}
+		for _, d := range a.Dependencies() {
+			if g, ok := generated[reflect.TypeOf(d)]; ok {
+				parents.Add(g)
+				continue
+			}
+			dependencyParents, err := generate(d)
+			if err != nil {
+				return nil, err
+			}
+			if err := d.Generate(dependencyParents); err != nil {
+				return nil, errors.Wrapf(err, "failed to generate %s", d.Name())
+			}
+			generated[reflect.TypeOf(d)] = d
+			parents.Add(d)
+		}
+		return parents, nil
+	}
+	return generate(a)
+}
@@ -0,0 +1,102 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func lintInstallConfig() *types.InstallConfig {
+	ic := testInstallConfig()
+	ic.APIVersion = types.InstallConfigVersion
+	return ic
+}
+
+func TestManifestsLint(t *testing.T) {
+	cases := []struct {
+		name           string
+		manifests      *Manifests
+		installConfig  func(*types.InstallConfig)
+		expectedErrors []string
+	}{
+		{
+			name:      "valid",
+			manifests: &Manifests{},
+		},
+		{
+			name:      "invalid install-config",
+			manifests: &Manifests{},
+			installConfig: func(ic *types.InstallConfig) {
+				ic.BaseDomain = ""
+				ic.PullSecret = "not-json"
+			},
+			expectedErrors: []string{
+				`invalid install-config: baseDomain: Invalid value: "": a DNS-1123 subdomain must consist of lower case alphanumeric characters, '-' or '.', and must start and end with an alphanumeric character (e.g. 'example.com', regex used for validation is '[a-z0-9]([-a-z0-9]*[a-z0-9])?(\.[a-z0-9]([-a-z0-9]*[a-z0-9])?)*')`,
+				`invalid install-config: pullSecret: Invalid value: "not-json": invalid character 'o' in literal null (expecting 'u')`,
+			},
+		},
+		{
+			name:           "mandatory manifest skipped",
+			manifests:      &Manifests{SkippedManifests: []string{"cvo-overrides.yaml"}},
+			expectedErrors: []string{"failed to generate Common Manifests: cvo-overrides.yaml is required by the installer and cannot be skipped"},
+		},
+		{
+			name: "manifest emptied by post-processing",
+			manifests: &Manifests{PostProcess: func(f *asset.File) error {
+				if f.Filename == "manifests/etcd-service.yaml" {
+					f.Data = nil
+				}
+				return nil
+			}},
+			expectedErrors: []string{"invalid manifests: manifests/etcd-service.yaml has no data"},
+		},
+		{
+			name:           "invalid etcd member hostnames",
+			manifests:      &Manifests{},
+			installConfig:  func(ic *types.InstallConfig) { ic.EtcdMemberHostnames = []string{"etcd-a"} },
+			expectedErrors: []string{"invalid install-config: etcdMemberHostnames: Invalid value: []string{\"etcd-a\"}: 3 hostnames are required, one for each control plane replica"},
+		},
+		{
+			name:      "valid etcd backup",
+			manifests: &Manifests{},
+			installConfig: func(ic *types.InstallConfig) {
+				ic.EtcdBackup = &types.EtcdBackup{Image: "quay.io/example/etcd:v3.3"}
+			},
+		},
+		{
+			name:      "invalid etcd backup schedule",
+			manifests: &Manifests{},
+			installConfig: func(ic *types.InstallConfig) {
+				ic.EtcdBackup = &types.EtcdBackup{Schedule: "every day", Image: "quay.io/example/etcd:v3.3"}
+			},
+			expectedErrors: []string{`invalid install-config: etcdBackup.schedule: Invalid value: "every day": expected 5 fields, found 2`},
+		},
+		{
+			name:      "alert receiver missing the webhook URL",
+			manifests: &Manifests{},
+			installConfig: func(ic *types.InstallConfig) {
+				ic.AlertReceiver = &types.AlertReceiver{Slack: &types.SlackAlertReceiver{Channel: "#alerts"}}
+			},
+			expectedErrors: []string{"invalid install-config: alertReceiver.slack.apiURL: Required value: the webhook URL is required"},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ic := lintInstallConfig()
+			if tc.installConfig != nil {
+				tc.installConfig(ic)
+			}
+			report := tc.manifests.Lint(ic)
+			var errs []string
+			for _, err := range report.Problems {
+				errs = append(errs, err.Error())
+			}
+			assert.Equal(t, tc.expectedErrors, errs, "unexpected problems")
+			assert.Equal(t, len(tc.expectedErrors) == 0, report.OK())
+			assert.Nil(t, tc.manifests.FileList, "the linted manifests were kept")
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package manifests
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// LoadFrom loads the manifests from several directories, such as a base set
+// and an environment-specific set, merging them into one FileList in the
+// manifests directory. The directories are in increasing precedence: when
+// several of them hold a file of the same name, that of the last one is
+// kept. KubeSysConfig is likewise the kube-system configmap of the last of
+// the directories that holds one, and the manifests are only found if one
+// of them does.
+func (m *Manifests) LoadFrom(dirs []string, f asset.FileFetcher) (bool, error) {
+	if len(dirs) == 0 {
+		return false, errors.New("no directories to load the manifests from")
+	}
+
+	dir := m.directory()
+	merged := map[string]*asset.File{}
+	var kubeSysConfig *configurationObject
+	for _, d := range dirs {
+		fileList, err := f.FetchByPattern(filepath.Join(d, "*"))
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to load the manifests from %s", d)
+		}
+		config, err := findKubeSysConfig(fileList, relocatedFilename(kubeSysConfigPath, d), m.kubeSysConfigName())
+		if err != nil {
+			return false, errors.Wrapf(err, "failed to load the manifests from %s", d)
+		}
+		if config != nil {
+			kubeSysConfig = config
+		}
+		for _, file := range fileList {
+			filename := relocatedFilename(file.Filename, dir)
+			merged[filename] = &asset.File{
+				Filename: filename,
+				Data:     file.Data,
+			}
+		}
+	}
+	if kubeSysConfig == nil {
+		return false, nil
+	}
+
+	fileList := make([]*asset.File, 0, len(merged))
+	for _, file := range merged {
+		fileList = append(fileList, file)
+	}
+	m.sortFiles(fileList)
+	m.FileList, m.KubeSysConfig = fileList, kubeSysConfig
+	return true, nil
+}
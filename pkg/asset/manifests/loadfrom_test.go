@@ -0,0 +1,127 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/mock"
+)
+
+func TestManifestsLoadFrom(t *testing.T) {
+	kubeSysConfig := func(installConfig string) (*configurationObject, []byte) {
+		cm := configMap("kube-system", "cluster-config-v1", genericData{"install-config": installConfig})
+		data, err := yaml.Marshal(cm)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cm, data
+	}
+	baseConfig, baseConfigData := kubeSysConfig("base")
+	envConfig, envConfigData := kubeSysConfig("env")
+
+	cases := []struct {
+		name                  string
+		dirs                  map[string][]*asset.File
+		expectedFound         bool
+		expectedFiles         []*asset.File
+		expectedKubeSysConfig *configurationObject
+	}{
+		{
+			name: "non-overlapping",
+			dirs: map[string][]*asset.File{
+				"base": {
+					{Filename: "base/cluster-config.yaml", Data: baseConfigData},
+					{Filename: "base/a.yaml", Data: []byte("a")},
+				},
+				"env": {
+					{Filename: "env/b.yaml", Data: []byte("b")},
+				},
+			},
+			expectedFound: true,
+			expectedFiles: []*asset.File{
+				{Filename: "manifests/a.yaml", Data: []byte("a")},
+				{Filename: "manifests/b.yaml", Data: []byte("b")},
+				{Filename: "manifests/cluster-config.yaml", Data: baseConfigData},
+			},
+			expectedKubeSysConfig: baseConfig,
+		},
+		{
+			name: "override",
+			dirs: map[string][]*asset.File{
+				"base": {
+					{Filename: "base/cluster-config.yaml", Data: baseConfigData},
+					{Filename: "base/a.yaml", Data: []byte("base a")},
+					{Filename: "base/b.yaml", Data: []byte("base b")},
+				},
+				"env": {
+					{Filename: "env/cluster-config.yaml", Data: envConfigData},
+					{Filename: "env/a.yaml", Data: []byte("env a")},
+				},
+			},
+			expectedFound: true,
+			expectedFiles: []*asset.File{
+				{Filename: "manifests/a.yaml", Data: []byte("env a")},
+				{Filename: "manifests/b.yaml", Data: []byte("base b")},
+				{Filename: "manifests/cluster-config.yaml", Data: envConfigData},
+			},
+			expectedKubeSysConfig: envConfig,
+		},
+		{
+			name: "configmap under another filename",
+			dirs: map[string][]*asset.File{
+				"base": {
+					{Filename: "base/cluster-config.yaml", Data: baseConfigData},
+				},
+				"env": {
+					{Filename: "env/00-cluster-config-v1.yaml", Data: envConfigData},
+				},
+			},
+			expectedFound: true,
+			expectedFiles: []*asset.File{
+				{Filename: "manifests/00-cluster-config-v1.yaml", Data: envConfigData},
+				{Filename: "manifests/cluster-config.yaml", Data: baseConfigData},
+			},
+			expectedKubeSysConfig: envConfig,
+		},
+		{
+			name: "no configmap",
+			dirs: map[string][]*asset.File{
+				"base": {{Filename: "base/a.yaml", Data: []byte("a")}},
+				"env":  {},
+			},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			for dir, files := range tc.dirs {
+				fileFetcher.EXPECT().FetchByPattern(filepath.Join(dir, "*")).Return(files, nil)
+			}
+
+			m := &Manifests{}
+			found, err := m.LoadFrom([]string{"base", "env"}, fileFetcher)
+			if !assert.NoError(t, err, "unexpected error from LoadFrom") {
+				return
+			}
+			assert.Equal(t, tc.expectedFound, found, "unexpected found value returned from LoadFrom")
+			assert.Equal(t, tc.expectedFiles, m.FileList, "unexpected FileList")
+			assert.Equal(t, tc.expectedKubeSysConfig, m.KubeSysConfig, "unexpected KubeSysConfig")
+		})
+	}
+}
+
+func TestManifestsLoadFromNoDirectories(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	_, err := (&Manifests{}).LoadFrom(nil, mock.NewMockFileFetcher(mockCtrl))
+	assert.EqualError(t, err, "no directories to load the manifests from")
+}
@@ -0,0 +1,59 @@
+package manifests
+
+import (
+	"time"
+)
+
+// GenerationPhase is a phase of generating the manifests.
+type GenerationPhase string
+
+const (
+	// PhaseRedaction is the redaction of the install-config stored in the
+	// cluster.
+	PhaseRedaction GenerationPhase = "redaction"
+
+	// PhaseBootkubeRendering is the rendering of the bootkube manifests.
+	PhaseBootkubeRendering GenerationPhase = "bootkube-rendering"
+
+	// PhaseAssetFiles is the collection of the files of one of the
+	// manifest assets, which is named by the sample.
+	PhaseAssetFiles GenerationPhase = "asset-files"
+)
+
+// TimingSample is the duration of a phase of generating the manifests.
+type TimingSample struct {
+	Phase GenerationPhase
+	// Asset is the name of the asset whose files were collected, for
+	// PhaseAssetFiles samples.
+	Asset    string
+	Duration time.Duration
+}
+
+// MetricsSink receives the timing samples of generating the manifests, for
+// example to export them to Prometheus. It must be safe for concurrent use
+// if it is shared by concurrent generations.
+type MetricsSink interface {
+	ObserveTiming(sample TimingSample)
+}
+
+type noopMetricsSink struct{}
+
+func (noopMetricsSink) ObserveTiming(TimingSample) {}
+
+// metricsSink returns the sink of the timing samples, which defaults to
+// discarding them.
+func (m *Manifests) metricsSink() MetricsSink {
+	if m.Metrics != nil {
+		return m.Metrics
+	}
+	return noopMetricsSink{}
+}
+
+// observeSince records the time since start as a sample of the phase.
+func observeSince(sink MetricsSink, phase GenerationPhase, asset string, start time.Time) {
+	sink.ObserveTiming(TimingSample{
+		Phase:    phase,
+		Asset:    asset,
+		Duration: time.Since(start),
+	})
+}
@@ -0,0 +1,55 @@
+package manifests
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type capturingMetricsSink struct {
+	mu      sync.Mutex
+	samples []TimingSample
+}
+
+func (s *capturingMetricsSink) ObserveTiming(sample TimingSample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.samples = append(s.samples, sample)
+}
+
+func TestManifestsMetrics(t *testing.T) {
+	sink := &capturingMetricsSink{}
+	m := &Manifests{Metrics: sink}
+	generateTestAsset(t, testInstallConfig(), m)
+
+	phases := map[GenerationPhase]int{}
+	assets := map[string]bool{}
+	for _, sample := range sink.samples {
+		phases[sample.Phase]++
+		if sample.Phase == PhaseAssetFiles {
+			assets[sample.Asset] = true
+		} else {
+			assert.Empty(t, sample.Asset, "unexpected asset for the %s phase", sample.Phase)
+		}
+		assert.True(t, sample.Duration >= 0, "negative duration for the %s phase", sample.Phase)
+	}
+	assert.Equal(t, 1, phases[PhaseRedaction], "unexpected redaction samples")
+	assert.Equal(t, 1, phases[PhaseBootkubeRendering], "unexpected bootkube rendering samples")
+	for _, a := range []string{
+		(&Ingress{}).Name(),
+		(&DNS{}).Name(),
+		(&Networking{}).Name(),
+		(&Infrastructure{}).Name(),
+		(&Proxy{}).Name(),
+		(&Scheduler{}).Name(),
+		(&ImageContentSourcePolicy{}).Name(),
+	} {
+		assert.True(t, assets[a], "missing files sample for %s", a)
+	}
+	assert.Equal(t, len(assets), phases[PhaseAssetFiles], "unexpected repeated files samples")
+}
+
+func TestManifestsDefaultMetricsSink(t *testing.T) {
+	assert.Equal(t, noopMetricsSink{}, (&Manifests{}).metricsSink())
+}
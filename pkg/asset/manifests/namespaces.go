@@ -0,0 +1,146 @@
+package manifests
+
+import (
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// controlPlaneNamespaces are the namespaces that exist as soon as the
+// control plane is up, so they need no Namespace manifest.
+var controlPlaneNamespaces = map[string]bool{
+	"default":         true,
+	"kube-node-lease": true,
+	"kube-public":     true,
+	"kube-system":     true,
+}
+
+// payloadNamespaces are the namespaces that the release payload creates,
+// with the labels and annotations their operators need, such as
+// openshift.io/run-level, so they must not be created ahead of it.
+var payloadNamespaces = map[string]bool{
+	"openshift-cluster-version": true,
+	"openshift-config":          true,
+	"openshift-config-managed":  true,
+	"openshift-monitoring":      true,
+}
+
+// clusterScopedKinds are the kinds of the cluster-scoped objects found in
+// the manifests, whose metadata.namespace, if any, is ignored by the API
+// server and names no namespace to create.
+var clusterScopedKinds = map[string]bool{
+	"APIServer":                true,
+	"ClusterRole":              true,
+	"ClusterRoleBinding":       true,
+	"ClusterVersion":           true,
+	"CustomResourceDefinition": true,
+	"DNS":                      true,
+	"FeatureGate":              true,
+	"ImageContentSourcePolicy": true,
+	"Infrastructure":           true,
+	"Ingress":                  true,
+	"MachineConfig":            true,
+	"Namespace":                true,
+	"Network":                  true,
+	"PriorityClass":            true,
+	"Proxy":                    true,
+	"Scheduler":                true,
+	"StorageClass":             true,
+}
+
+// namespaceFilename returns the name of the Namespace manifest of the
+// namespace. The prefix sorts the manifests before the objects in the
+// namespaces.
+func namespaceFilename(dir, namespace string) string {
+	return filepath.Join(dir, fmt.Sprintf("00-namespace-%s.yaml", namespace))
+}
+
+// namespaceObject is the part of an object, or of a v1 List of objects,
+// that names the namespaces it creates or is in.
+type namespaceObject struct {
+	Kind     string            `json:"kind"`
+	Metadata metadata          `json:"metadata"`
+	Items    []namespaceObject `json:"items"`
+}
+
+// templateActions matches the actions of a bootkube template.
+var templateActions = regexp.MustCompile(`{{.*?}}`)
+
+// withoutTemplateActions returns a copy of the template with its actions
+// removed. The object metadata of the bootkube templates is not templated, so
+// the copy names the same namespaces as the rendered manifest.
+func withoutTemplateActions(template *asset.File) *asset.File {
+	return &asset.File{
+		Filename: template.Filename,
+		Data:     templateActions.ReplaceAll(template.Data, nil),
+	}
+}
+
+// namespaceFiles returns the Namespace manifests, sorted by name, of the
+// namespaces that the namespaced objects of the files are in but that
+// neither the control plane, the release payload nor the files create.
+// Documents that do not parse are ignored.
+func namespaceFiles(files []*asset.File, dir string) ([]*asset.File, error) {
+	created := map[string]bool{}
+	referenced := map[string]bool{}
+	var scan func(obj namespaceObject)
+	scan = func(obj namespaceObject) {
+		if obj.Kind == "Namespace" {
+			created[obj.Metadata.Name] = true
+		}
+		if obj.Metadata.Namespace != "" && !clusterScopedKinds[obj.Kind] {
+			referenced[obj.Metadata.Namespace] = true
+		}
+		for _, item := range obj.Items {
+			scan(item)
+		}
+	}
+	for _, f := range files {
+		for _, doc := range documentSeparator.Split(string(f.Data), -1) {
+			var obj namespaceObject
+			if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+				continue
+			}
+			scan(obj)
+		}
+	}
+
+	namespaces := []string{}
+	for namespace := range referenced {
+		if !created[namespace] && !controlPlaneNamespaces[namespace] && !payloadNamespaces[namespace] {
+			namespaces = append(namespaces, namespace)
+		}
+	}
+	sort.Strings(namespaces)
+
+	namespaceFiles := make([]*asset.File, len(namespaces))
+	for i, namespace := range namespaces {
+		data, err := yaml.Marshal(struct {
+			metav1.TypeMeta `json:",inline"`
+			Metadata        metadata `json:"metadata"`
+		}{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "Namespace",
+			},
+			Metadata: metadata{
+				Name: namespace,
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create the %s namespace", namespace)
+		}
+		namespaceFiles[i] = &asset.File{
+			Filename: namespaceFilename(dir, namespace),
+			Data:     data,
+		}
+	}
+	return namespaceFiles, nil
+}
@@ -0,0 +1,110 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestNamespaceFiles(t *testing.T) {
+	files := []*asset.File{
+		{Filename: "manifests/a.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n  namespace: referenced\n")},
+		{Filename: "manifests/b.yaml", Data: []byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: b\n  namespace: referenced\n")},
+		{Filename: "manifests/c.yaml", Data: []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: created\n---\napiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: c\n  namespace: created\n")},
+		{Filename: "manifests/d.yaml", Data: []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: d\n  namespace: kube-system\n")},
+		{Filename: "manifests/e.json", Data: []byte(`{"apiVersion": "v1", "kind": "List", "items": [{"apiVersion": "v1", "kind": "ConfigMap", "metadata": {"name": "e", "namespace": "listed"}}]}`)},
+		{Filename: "manifests/f.yaml", Data: []byte("apiVersion: v1\nkind: ClusterRole\nmetadata:\n  name: f\n")},
+		{Filename: "manifests/g.yaml", Data: []byte("not: [valid\n")},
+		{Filename: "manifests/h.yaml", Data: []byte("apiVersion: config.openshift.io/v1\nkind: ClusterVersion\nmetadata:\n  name: version\n  namespace: cluster-scoped\n")},
+		{Filename: "manifests/i.yaml", Data: []byte("apiVersion: v1\nkind: Secret\nmetadata:\n  name: i\n  namespace: openshift-config\n")},
+	}
+
+	namespaces, err := namespaceFiles(files, manifestDir)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, []*asset.File{
+		{
+			Filename: "manifests/00-namespace-listed.yaml",
+			Data:     []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: listed\n"),
+		},
+		{
+			Filename: "manifests/00-namespace-referenced.yaml",
+			Data:     []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: referenced\n"),
+		},
+	}, namespaces)
+}
+
+func TestManifestsNamespaces(t *testing.T) {
+	m := &Manifests{}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	parents.Add(&UserManifests{
+		FileList: []*asset.File{{
+			Filename: "openshift/99-user-configmap.yaml",
+			Data:     []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: user\n  namespace: user-namespace\n"),
+		}},
+	})
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+
+	files := m.FileMap()
+	for _, namespace := range []string{"user-namespace"} {
+		f, ok := files[namespaceFilename(manifestDir, namespace)]
+		if assert.True(t, ok, "missing Namespace manifest for %s", namespace) {
+			assert.Contains(t, string(f.Data), "kind: Namespace")
+			assert.Contains(t, string(f.Data), "name: "+namespace)
+		}
+	}
+	// The namespaces created by the bootkube manifests, the control plane
+	// and the release payload are not created again, and neither are those
+	// named by cluster-scoped objects, such as the ClusterVersion.
+	for _, namespace := range []string{"openshift-etcd", "openshift-machine-config-operator", "kube-system", "openshift-config", "openshift-cluster-version"} {
+		_, ok := files[namespaceFilename(manifestDir, namespace)]
+		assert.False(t, ok, "unexpected Namespace manifest for %s", namespace)
+	}
+	_, ok := files[filepath.Join(manifestDir, "etcd-namespace.yaml")]
+	assert.True(t, ok, "missing etcd namespace")
+}
+
+// testNamespacedTemplate is a bootkube template asset of an object in a
+// namespace that nothing else creates. It cannot be rendered, as it refers to
+// a field the template data does not have.
+type testNamespacedTemplate struct {
+	FileList []*asset.File
+}
+
+func (t *testNamespacedTemplate) Dependencies() []asset.Asset { return []asset.Asset{} }
+
+func (t *testNamespacedTemplate) Name() string { return "TestNamespacedTemplate" }
+
+func (t *testNamespacedTemplate) Generate(asset.Parents) error {
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join("templates", "test-configmap.yaml.template"),
+			Data:     []byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n  namespace: test-namespace\ndata:\n  value: {{.NoSuchField}}\n"),
+		},
+	}
+	return nil
+}
+
+func (t *testNamespacedTemplate) Files() []*asset.File { return t.FileList }
+
+func (t *testNamespacedTemplate) Load(asset.FileFetcher) (bool, error) { return false, nil }
+
+func TestExpectedFilenamesNamespaces(t *testing.T) {
+	defer func(registered []func() asset.WritableAsset) {
+		registeredBootkubeTemplates = registered
+	}(registeredBootkubeTemplates)
+	RegisterBootkubeTemplate(func() asset.WritableAsset { return &testNamespacedTemplate{} })
+
+	// The templates are scanned, not rendered.
+	expected, err := (&Manifests{}).ExpectedFilenames()
+	if assert.NoError(t, err) {
+		assert.Contains(t, expected, filepath.Join(manifestDir, "test-configmap.yaml"))
+		assert.Contains(t, expected, namespaceFilename(manifestDir, "test-namespace"))
+	}
+}
@@ -0,0 +1,83 @@
+package manifests
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// documentRank returns the position of the YAML document among those of its
+// file: Namespaces come first, then CustomResourceDefinitions, then every
+// other object, so that consumers applying the documents from top to bottom
+// create the namespaces and types of the objects before the objects.
+// Documents that do not parse rank with the other objects.
+func documentRank(doc string) int {
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal([]byte(doc), &typeMeta); err != nil {
+		return 2
+	}
+	switch {
+	case typeMeta.APIVersion == "v1" && typeMeta.Kind == "Namespace":
+		return 0
+	case strings.HasPrefix(typeMeta.APIVersion, "apiextensions.k8s.io/") && typeMeta.Kind == "CustomResourceDefinition":
+		return 1
+	default:
+		return 2
+	}
+}
+
+// orderDocuments returns the files with the documents of each multi-document
+// YAML file ordered by documentRank. The sort is stable, so documents of the
+// same rank keep their order. Files that are already ordered are returned
+// unchanged, and the others are copied rather than modified.
+func orderDocuments(files []*asset.File, sources map[*asset.File]string) []*asset.File {
+	ordered := make([]*asset.File, len(files))
+	for i, f := range files {
+		ordered[i] = orderFileDocuments(f)
+		if sources != nil {
+			sources[ordered[i]] = sources[f]
+		}
+	}
+	return ordered
+}
+
+func orderFileDocuments(f *asset.File) *asset.File {
+	docs := []string{}
+	for _, doc := range documentSeparator.Split(string(f.Data), -1) {
+		if strings.TrimSpace(doc) != "" {
+			docs = append(docs, doc)
+		}
+	}
+	if len(docs) < 2 {
+		return f
+	}
+	ranks := make([]int, len(docs))
+	for i, doc := range docs {
+		ranks[i] = documentRank(doc)
+	}
+	if sort.IntsAreSorted(ranks) {
+		return f
+	}
+
+	indexes := make([]int, len(docs))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool { return ranks[indexes[i]] < ranks[indexes[j]] })
+	var b strings.Builder
+	for i, index := range indexes {
+		if i > 0 {
+			b.WriteString("---\n")
+		}
+		b.WriteString(strings.TrimSuffix(strings.TrimPrefix(docs[index], "\n"), "\n"))
+		b.WriteString("\n")
+	}
+	return &asset.File{
+		Filename: f.Filename,
+		Data:     []byte(b.String()),
+	}
+}
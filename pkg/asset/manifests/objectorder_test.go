@@ -0,0 +1,142 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestOrderDocuments(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name: "custom resource before its definition",
+			data: `apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: a
+  namespace: widgets
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+`,
+			expected: `apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+---
+apiVersion: example.com/v1
+kind: Widget
+metadata:
+  name: a
+  namespace: widgets
+`,
+		},
+		{
+			name: "stable order of each kind",
+			data: `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: first
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: widgets
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: second
+`,
+			expected: `apiVersion: v1
+kind: Namespace
+metadata:
+  name: widgets
+---
+apiVersion: apiextensions.k8s.io/v1beta1
+kind: CustomResourceDefinition
+metadata:
+  name: first
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: second
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: a
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: b
+`,
+		},
+		{
+			name:     "already ordered",
+			data:     "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: a\n---\n# a comment\napiVersion: v1\nkind: ConfigMap\n",
+			expected: "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: a\n---\n# a comment\napiVersion: v1\nkind: ConfigMap\n",
+		},
+		{
+			name:     "single document",
+			data:     "apiVersion: v1\nkind: ConfigMap\n",
+			expected: "apiVersion: v1\nkind: ConfigMap\n",
+		},
+		{
+			name:     "documents that do not parse",
+			data:     "not: [valid\n---\napiVersion: v1\nkind: Namespace\n",
+			expected: "apiVersion: v1\nkind: Namespace\n---\nnot: [valid\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &asset.File{Filename: "openshift/99-test.yaml", Data: []byte(tc.data)}
+			sources := map[*asset.File]string{f: "Test"}
+			ordered := orderDocuments([]*asset.File{f}, sources)
+			if assert.Len(t, ordered, 1) {
+				assert.Equal(t, f.Filename, ordered[0].Filename)
+				assert.Equal(t, tc.expected, string(ordered[0].Data))
+				assert.Equal(t, "Test", sources[ordered[0]])
+			}
+			assert.Equal(t, tc.data, string(f.Data), "the file was modified")
+		})
+	}
+}
+
+func TestManifestsOrderUserManifestDocuments(t *testing.T) {
+	m := &Manifests{}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	parents.Add(&UserManifests{
+		FileList: []*asset.File{{
+			Filename: "openshift/99-widgets.yaml",
+			Data:     []byte("apiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: a\n---\napiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: widgets.example.com\n"),
+		}},
+	})
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+	assert.Equal(t,
+		"apiVersion: apiextensions.k8s.io/v1\nkind: CustomResourceDefinition\nmetadata:\n  name: widgets.example.com\n---\napiVersion: example.com/v1\nkind: Widget\nmetadata:\n  name: a\n",
+		string(m.FileMap()[filepath.Join(manifestDir, "99-widgets.yaml")].Data))
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
 
 	"github.com/gophercloud/utils/openstack/clientconfig"
 	"github.com/openshift/installer/pkg/asset"
@@ -166,24 +167,28 @@ func (o *Openshift) Generate(dependencies asset.Parents) error {
 		kubeadminPasswordSecret,
 		roleCloudCredsSecretReader)
 
-	assetData := map[string][]byte{
-		"99_kubeadmin-password-secret.yaml": applyTemplateData(kubeadminPasswordSecret.Files()[0].Data, templateData),
+	assetTemplates := map[string][]byte{
+		"99_kubeadmin-password-secret.yaml": kubeadminPasswordSecret.Files()[0].Data,
 	}
 
 	switch platform {
 	case awstypes.Name, openstacktypes.Name, vspheretypes.Name, azuretypes.Name, gcptypes.Name:
-		assetData["99_cloud-creds-secret.yaml"] = applyTemplateData(cloudCredsSecret.Files()[0].Data, templateData)
-		assetData["99_role-cloud-creds-secret-reader.yaml"] = applyTemplateData(roleCloudCredsSecretReader.Files()[0].Data, templateData)
+		assetTemplates["99_cloud-creds-secret.yaml"] = cloudCredsSecret.Files()[0].Data
+		assetTemplates["99_role-cloud-creds-secret-reader.yaml"] = roleCloudCredsSecretReader.Files()[0].Data
 	}
 
 	if platform == azuretypes.Name && installConfig.Config.Publish == types.InternalPublishingStrategy {
 		privateClusterOutbound := &openshift.PrivateClusterOutbound{}
 		dependencies.Get(privateClusterOutbound)
-		assetData["99_private-cluster-outbound-service.yaml"] = applyTemplateData(privateClusterOutbound.Files()[0].Data, templateData)
+		assetTemplates["99_private-cluster-outbound-service.yaml"] = privateClusterOutbound.Files()[0].Data
 	}
 
 	o.FileList = []*asset.File{}
-	for name, data := range assetData {
+	for name, tmpl := range assetTemplates {
+		data, err := applyTemplateData(tmpl, templateData)
+		if err != nil {
+			return errors.Wrapf(err, "failed to render %s", name)
+		}
 		if len(data) == 0 {
 			continue
 		}
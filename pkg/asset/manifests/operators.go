@@ -3,24 +3,46 @@ package manifests
 
 import (
 	"bytes"
+	"crypto/rsa"
+	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
 	"path/filepath"
+	"reflect"
+	"runtime"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
 
 	"github.com/ghodss/yaml"
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 
+	configv1 "github.com/openshift/api/config/v1"
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/openshift/installer/pkg/asset/installconfig"
 	"github.com/openshift/installer/pkg/asset/templates/content/bootkube"
 	"github.com/openshift/installer/pkg/asset/tls"
 	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/baremetal"
+	"github.com/openshift/installer/pkg/validate"
 )
 
 const (
 	manifestDir = "manifests"
+
+	defaultKubeSysConfigName = "cluster-config-v1"
+
+	defaultControlPlaneReplicas = 3
 )
 
 var (
@@ -29,15 +51,121 @@ var (
 	_ asset.WritableAsset = (*Manifests)(nil)
 
 	customTmplFuncs = template.FuncMap{
-		"indent": indent,
+		"indent":  indent,
+		"nindent": nindent,
 		"add": func(i, j int) int {
 			return i + j
 		},
+		"sub": func(i, j int) int {
+			return i - j
+		},
+		"mod":        mod,
+		"quantity":   quantity,
+		"duration":   duration,
+		"toYAML":     toYAML,
+		"jsonBase64": jsonBase64,
+		// YAML double-quoted scalars accept all of Go's string escapes.
+		"quote":     strconv.Quote,
+		"shortHash": shortHash,
+		"dns1123":   dns1123,
+		"dataURI":   dataURI,
+		"default":   defaultValue,
+		"join":      join,
+		"lookup":    lookup,
+		// A subset of the sprig string functions, with the same signatures,
+		// for templates written for Helm.
+		"upper":      strings.ToUpper,
+		"lower":      strings.ToLower,
+		"trim":       strings.TrimSpace,
+		"trimPrefix": trimPrefix,
+		"trimSuffix": trimSuffix,
+		"replace":    replace,
 	}
 )
 
 // Manifests generates the dependent operator config.yaml files
 type Manifests struct {
+	// Directory is the directory the manifests are generated into and loaded
+	// from. It defaults to "manifests".
+	Directory string
+
+	// RedactedFields are the selectors of additional install-config fields
+	// to blank in the cluster-config configmap, such as
+	// "platform.aws.userTags.token". The pull secret and platform
+	// credentials are always blanked.
+	RedactedFields []string
+
+	// Format is the serialization of the generated files. It defaults to
+	// YAML.
+	Format OutputFormat
+
+	// SkippedManifests are the filenames of bootkube manifests, such as
+	// "etcd-service.yaml", that are not generated. The manifests the
+	// installer requires cannot be skipped. The skipped manifests are
+	// recorded in an annotation on the cluster-config configmap.
+	SkippedManifests []string
+
+	// KubeSysConfigName is the name of the kube-system configmap holding
+	// the install-config, which is also the name Load looks for. It
+	// defaults to cluster-config-v1, which is the name the cluster reads,
+	// and may be changed so that the manifests can be applied to a sandbox
+	// without colliding with that of a real cluster.
+	KubeSysConfigName string
+
+	// KubeSysConfigLabels and KubeSysConfigAnnotations are added to the
+	// metadata of the kube-system cluster-config configmap, for example to
+	// record which fleet the cluster belongs to. Annotations the installer
+	// sets take precedence.
+	KubeSysConfigLabels      map[string]string
+	KubeSysConfigAnnotations map[string]string
+
+	// PostProcess, when set, is called with a copy of each generated file,
+	// including the user manifests, which it may modify in place. It is
+	// called after the files are serialized in the output format and before
+	// the checksums are computed, and must not rename the file.
+	PostProcess func(*asset.File) error
+
+	// SealedSecretsKey, when set, is the public key of a Bitnami Sealed
+	// Secrets controller. Each generated Secret is replaced by a
+	// SealedSecret that only that controller can unseal. The Secrets the
+	// cluster is bootstrapped with, such as the pull secret and the etcd and
+	// machine-config-server TLS secrets, are needed before the controller
+	// runs and are not sealed, and neither are the user manifests.
+	SealedSecretsKey *rsa.PublicKey
+
+	// TargetKubernetesVersion is the Kubernetes release, such as 1.21, of
+	// the control plane the manifests are generated for. It selects the
+	// apiVersions of the objects whose API group version differs between
+	// releases, and defaults to 1.16.
+	TargetKubernetesVersion string
+
+	// ExternalPullSecret, when set, references a pull secret managed
+	// outside the installer. The openshift-config/pull-secret manifest is
+	// then an ExternalSecret syncing it, rather than a Secret embedding the
+	// pull secret of the install-config.
+	ExternalPullSecret *ExternalPullSecretReference
+
+	// Metrics, when set, receives the durations of the phases of each
+	// generation.
+	Metrics MetricsSink
+
+	// PlaceholderTLS, when set, renders the manifests with deterministic
+	// placeholders in place of the certificates and keys, so that their
+	// structure can be reviewed without generating or holding real TLS
+	// material. The TLS assets need not be among the parents. Every
+	// generated object is annotated with
+	// installer.openshift.io/placeholder-tls, and the manifests cannot be
+	// used to install a cluster.
+	PlaceholderTLS bool
+
+	// FilePriority, when set, orders FileList for consumers that apply the
+	// manifests in order, such as GitOps tools: files of a lower priority
+	// come first, and files of the same priority are ordered by name. It
+	// is called once for each file whenever FileList is sorted, and
+	// KindPriority builds one from the kinds of the objects. By default,
+	// the files are only ordered by name.
+	FilePriority func(*asset.File) int
+
 	KubeSysConfig *configurationObject
 	FileList      []*asset.File
 }
@@ -52,7 +180,7 @@ func (m *Manifests) Name() string {
 // Dependencies returns all of the dependencies directly needed by a
 // Manifests asset.
 func (m *Manifests) Dependencies() []asset.Asset {
-	return []asset.Asset{
+	dependencies := []asset.Asset{
 		&installconfig.ClusterID{},
 		&installconfig.InstallConfig{},
 		&Ingress{},
@@ -62,37 +190,144 @@ func (m *Manifests) Dependencies() []asset.Asset {
 		&Proxy{},
 		&Scheduler{},
 		&ImageContentSourcePolicy{},
-		&tls.RootCA{},
-		&tls.EtcdSignerCertKey{},
-		&tls.EtcdCABundle{},
-		&tls.EtcdSignerClientCertKey{},
-		&tls.EtcdMetricCABundle{},
-		&tls.EtcdMetricSignerCertKey{},
-		&tls.EtcdMetricSignerClientCertKey{},
-		&tls.MCSCertKey{},
+		&UserManifests{},
+	}
+	// The placeholders stand in for the TLS assets, which are then not
+	// generated at all.
+	if !m.PlaceholderTLS {
+		dependencies = append(dependencies,
+			&tls.RootCA{},
+			&tls.EtcdSignerCertKey{},
+			&tls.EtcdCABundle{},
+			&tls.EtcdSignerClientCertKey{},
+			&tls.EtcdMetricCABundle{},
+			&tls.EtcdMetricSignerCertKey{},
+			&tls.EtcdMetricSignerClientCertKey{},
+			&tls.MCSCertKey{},
+		)
+	}
+	for _, a := range conditionalBootkubeTemplates() {
+		dependencies = append(dependencies, a)
+	}
+	for _, a := range bootkubeTemplates() {
+		dependencies = append(dependencies, a)
+	}
+	return dependencies
+}
 
+// registeredBootkubeTemplates holds the constructors of the bootkube template
+// assets registered by other packages.
+var registeredBootkubeTemplates []func() asset.WritableAsset
+
+// RegisterBootkubeTemplate adds a bootkube template asset that is rendered
+// into the manifests directory along with the built-in ones. The constructor
+// must return a new asset of a type that is not already registered. It is not
+// safe for concurrent use and is meant to be called from init functions.
+func RegisterBootkubeTemplate(newAsset func() asset.WritableAsset) {
+	registeredBootkubeTemplates = append(registeredBootkubeTemplates, newAsset)
+}
+
+// bootkubeTemplates returns the bootkube template assets that are rendered
+// into the manifests directory.
+func bootkubeTemplates() []asset.WritableAsset {
+	templates := []asset.WritableAsset{
 		&bootkube.CVOOverrides{},
 		&bootkube.EtcdCAConfigMap{},
 		&bootkube.EtcdClientSecret{},
 		&bootkube.EtcdHostServiceEndpoints{},
 		&bootkube.EtcdHostService{},
 		&bootkube.EtcdMetricClientSecret{},
-		&bootkube.EtcdMetricServingCAConfigMap{},
 		&bootkube.EtcdMetricSignerSecret{},
+		&bootkube.EtcdMetricServingCAConfigMap{},
 		&bootkube.EtcdNamespace{},
 		&bootkube.EtcdService{},
+		&bootkube.EtcdServingCAConfigMap{},
 		&bootkube.EtcdSignerSecret{},
 		&bootkube.KubeCloudConfig{},
-		&bootkube.EtcdServingCAConfigMap{},
 		&bootkube.KubeSystemConfigmapRootCA{},
 		&bootkube.MachineConfigServerTLSSecret{},
+		&bootkube.MachineConfigServerTLSSecretReaderRole{},
+		&bootkube.MachineConfigServerTLSSecretReaderRoleBinding{},
 		&bootkube.OpenshiftConfigSecretPullSecret{},
 		&bootkube.OpenshiftMachineConfigOperator{},
 	}
+	for _, newAsset := range registeredBootkubeTemplates {
+		templates = append(templates, newAsset())
+	}
+	return templates
+}
+
+// conditionalBootkubeTemplates returns the bootkube template assets whose
+// manifests depend on the install-config, and that bootkubeTemplates does
+// not return for that reason.
+func conditionalBootkubeTemplates() []asset.WritableAsset {
+	return []asset.WritableAsset{
+		&bootkube.EtcdPodDisruptionBudget{},
+		&bootkube.EtcdBackupClientSecret{},
+		&bootkube.EtcdBackupCronJob{},
+		&bootkube.EtcdBackupServiceAccount{},
+		&bootkube.EtcdBackupRole{},
+		&bootkube.EtcdBackupRoleBinding{},
+		&bootkube.AlertmanagerMainSecret{},
+		&bootkube.EtcdServiceMonitor{},
+		&bootkube.EtcdMonitoringClientSecret{},
+		&bootkube.EtcdMonitoringServingCAConfigMap{},
+	}
+}
+
+// conditionalBootkubeTemplateEnabled returns whether the conditional bootkube
+// template is generated for the install-config.
+func conditionalBootkubeTemplateEnabled(a asset.WritableAsset, ic *types.InstallConfig) bool {
+	inClusterEtcd := ic.ExternalEtcd == nil
+	switch a.(type) {
+	case *bootkube.EtcdPodDisruptionBudget:
+		// A disruption budget cannot protect the quorum of fewer members,
+		// so single-replica control planes never get one.
+		return inClusterEtcd && len(EtcdEndpointHostnames(ic)) >= 3
+	case *bootkube.EtcdBackupClientSecret, *bootkube.EtcdBackupCronJob, *bootkube.EtcdBackupServiceAccount, *bootkube.EtcdBackupRole, *bootkube.EtcdBackupRoleBinding:
+		return inClusterEtcd && ic.EtcdBackup != nil
+	case *bootkube.AlertmanagerMainSecret:
+		return ic.AlertReceiver != nil
+	case *bootkube.EtcdServiceMonitor, *bootkube.EtcdMonitoringClientSecret, *bootkube.EtcdMonitoringServingCAConfigMap:
+		return inClusterEtcd && ic.ClusterMonitoring
+	}
+	return false
 }
 
 // Generate generates the respective operator config.yml files
 func (m *Manifests) Generate(dependencies asset.Parents) error {
+	files := []*asset.File{}
+	kubeSysConfig, err := m.generate(dependencies, false, func(f *asset.File) error {
+		files = append(files, f)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	m.sortFiles(files)
+	m.KubeSysConfig, m.FileList = kubeSysConfig, files
+	return nil
+}
+
+// GenerateMetadataOnly returns the files that Generate would produce, with
+// the keys, certificates and pull secret blanked out. The result is meant for
+// previewing or diffing the manifests and is not stored in the asset.
+func (m *Manifests) GenerateMetadataOnly(dependencies asset.Parents) ([]*asset.File, error) {
+	files := []*asset.File{}
+	if _, err := m.generate(dependencies, true, func(f *asset.File) error {
+		files = append(files, f)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	m.sortFiles(files)
+	return files, nil
+}
+
+// generate renders the manifests and hands each finished file to emit, in
+// the order of sortFiles, with the checksums file last. It returns the
+// kube-system configmap.
+func (m *Manifests) generate(dependencies asset.Parents, redact bool, emit func(*asset.File) error) (*configurationObject, error) {
 	ingress := &Ingress{}
 	dns := &DNS{}
 	network := &Networking{}
@@ -101,40 +336,231 @@ func (m *Manifests) Generate(dependencies asset.Parents) error {
 	proxy := &Proxy{}
 	scheduler := &Scheduler{}
 	imageContentSourcePolicy := &ImageContentSourcePolicy{}
-	dependencies.Get(installConfig, ingress, dns, network, infra, proxy, scheduler, imageContentSourcePolicy)
+	userManifests := &UserManifests{}
+	dependencies.Get(installConfig, ingress, dns, network, infra, proxy, scheduler, imageContentSourcePolicy, userManifests)
+
+	if m.PlaceholderTLS {
+		logrus.Warn("Generating manifests with placeholder TLS assets, which cannot be used to install a cluster")
+	}
 
-	redactedConfig, err := redactedInstallConfig(*installConfig.Config)
+	metrics := m.metricsSink()
+	start := time.Now()
+	redactedConfig, err := redactedInstallConfig(*installConfig.Config, m.RedactedFields)
+	observeSince(metrics, PhaseRedaction, "", start)
 	if err != nil {
-		return errors.Wrap(err, "failed to redact install-config")
+		return nil, &RedactionError{Err: errors.Wrap(err, "failed to redact install-config")}
+	}
+	// The check is only paid for when debugging.
+	if logrus.IsLevelEnabled(logrus.DebugLevel) {
+		if err := verifyRedactedInstallConfig(installConfig.Config, redactedConfig, m.RedactedFields); err != nil {
+			return nil, &RedactionError{Err: err}
+		}
 	}
 	// mao go to kube-system config map
-	m.KubeSysConfig = configMap("kube-system", "cluster-config-v1", genericData{
+	kubeSysConfigName := m.kubeSysConfigName()
+	if errs := validation.IsDNS1123Subdomain(kubeSysConfigName); len(errs) > 0 {
+		return nil, errors.Errorf("invalid kube-system configmap name %q: %s", kubeSysConfigName, strings.Join(errs, ", "))
+	}
+	kubeSysConfig := configMap("kube-system", kubeSysConfigName, genericData{
 		"install-config": string(redactedConfig),
 	})
-	kubeSysConfigData, err := yaml.Marshal(m.KubeSysConfig)
+	if err := validateObjectMetadata(m.KubeSysConfigLabels, m.KubeSysConfigAnnotations); err != nil {
+		return nil, errors.Wrapf(err, "invalid kube-system/%s configmap metadata", kubeSysConfigName)
+	}
+	kubeSysConfig.Metadata.Labels = copyStringMap(m.KubeSysConfigLabels)
+	kubeSysConfig.Metadata.Annotations = copyStringMap(m.KubeSysConfigAnnotations)
+	kubeSysConfigData, err := yaml.Marshal(kubeSysConfig)
 	if err != nil {
-		return errors.Wrap(err, "failed to create kube-system/cluster-config-v1 configmap")
+		return nil, errors.Wrapf(err, "failed to create kube-system/%s configmap", kubeSysConfigName)
 	}
 
-	m.FileList = []*asset.File{
-		{
-			Filename: kubeSysConfigPath,
-			Data:     kubeSysConfigData,
-		},
+	dir := m.directory()
+	kubeSysConfigFile := &asset.File{
+		Filename: relocatedFilename(kubeSysConfigPath, dir),
+		Data:     kubeSysConfigData,
+	}
+	// sources maps each generated file to the asset it came from
+	sources := map[*asset.File]string{
+		kubeSysConfigFile: installConfig.Name(),
 	}
-	m.FileList = append(m.FileList, m.generateBootKubeManifests(dependencies)...)
 
-	m.FileList = append(m.FileList, ingress.Files()...)
-	m.FileList = append(m.FileList, dns.Files()...)
-	m.FileList = append(m.FileList, network.Files()...)
-	m.FileList = append(m.FileList, infra.Files()...)
-	m.FileList = append(m.FileList, proxy.Files()...)
-	m.FileList = append(m.FileList, scheduler.Files()...)
-	m.FileList = append(m.FileList, imageContentSourcePolicy.Files()...)
+	provenanceFile, err := installerProvenanceFile(m.Dependencies())
+	if err != nil {
+		return nil, err
+	}
+	provenanceFile = relocatedFile(provenanceFile, dir)
+	sources[provenanceFile] = m.Name()
 
-	asset.SortFiles(m.FileList)
+	start = time.Now()
+	bootKubeFiles, err := m.generateBootKubeManifests(dependencies, dir, sources, redact)
+	observeSince(metrics, PhaseBootkubeRendering, "", start)
+	if err != nil {
+		return nil, err
+	}
 
-	return nil
+	readinessFile, err := bootstrapReadinessFile(bootKubeFiles)
+	if err != nil {
+		return nil, err
+	}
+	readinessFile = relocatedFile(readinessFile, dir)
+	sources[readinessFile] = m.Name()
+
+	files := []*asset.File{kubeSysConfigFile, provenanceFile, readinessFile}
+	files = append(files, bootKubeFiles...)
+
+	for _, a := range []asset.WritableAsset{ingress, dns, network, infra, proxy, scheduler, imageContentSourcePolicy} {
+		start := time.Now()
+		assetFiles := a.Files()
+		observeSince(metrics, PhaseAssetFiles, a.Name(), start)
+		for _, f := range assetFiles {
+			f = relocatedFile(f, dir)
+			sources[f] = a.Name()
+			files = append(files, f)
+		}
+	}
+
+	if err := verifySecretTypes(files); err != nil {
+		return nil, err
+	}
+
+	// User manifests are added unchanged, so only the generated files
+	// carry the provenance annotations and the critical PriorityClass.
+	for i, f := range files {
+		prioritized, err := setCriticalPriorityClass(f)
+		if err != nil {
+			return nil, err
+		}
+		annotated, err := m.annotateGenerated(prioritized, sources[f])
+		if err != nil {
+			return nil, err
+		}
+		sources[annotated] = sources[f]
+		files[i] = annotated
+	}
+	// The skipped manifests are recorded on the cluster-config configmap,
+	// which is the first of the files.
+	if skipped := m.skippedManifestsAnnotationValue(); skipped != "" {
+		annotated, err := annotateFile(files[0], map[string]string{skippedManifestsAnnotation: skipped})
+		if err != nil {
+			return nil, err
+		}
+		sources[annotated] = sources[files[0]]
+		files[0] = annotated
+	}
+
+	if m.SealedSecretsKey != nil {
+		files, err = sealSecrets(files, m.SealedSecretsKey, sources)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	userFilesStart := len(files)
+	files, err = appendUserManifests(dir, files, userManifests.Files())
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range files[userFilesStart:] {
+		sources[f] = userManifests.Name()
+	}
+
+	// The namespaces are only created for the objects that need them, user
+	// manifests included.
+	namespaces, err := namespaceFiles(files, dir)
+	if err != nil {
+		return nil, err
+	}
+	// The quotas are only for the namespaces the installer creates, not for
+	// those the user manifests create.
+	quotas, err := resourceQuotaFiles(installConfig.Config.ResourceQuotas, createdNamespaces(append(files[:userFilesStart:userFilesStart], namespaces...)), dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range append(namespaces, quotas...) {
+		annotated, err := m.annotateGenerated(f, m.Name())
+		if err != nil {
+			return nil, err
+		}
+		sources[annotated] = m.Name()
+		files = append(files, annotated)
+	}
+
+	if err := checkDuplicateFilenames(files, sources); err != nil {
+		return nil, err
+	}
+
+	// The files are finished and handed to emit one at a time, so that each
+	// can be released before the next is finished.
+	m.sortFiles(files)
+	fileSources := make([]string, len(files))
+	for i, f := range files {
+		fileSources[i] = sources[f]
+	}
+	sources = nil
+
+	logger := logrus.WithField("asset", m.Name())
+	checksums := make(map[string]string, len(files))
+	for i := range files {
+		f := orderFileDocuments(files[i])
+		files[i] = nil
+		converted, err := convertFiles([]*asset.File{f}, m.Format)
+		if err != nil {
+			return nil, err
+		}
+		processed, err := m.postProcessFiles(converted, nil)
+		if err != nil {
+			return nil, err
+		}
+		f = processed[0]
+		// The configmap matches the file it is written to, as it does when it
+		// is loaded back.
+		if f.Filename == kubeSysConfigFile.Filename {
+			kubeSysConfig, err = findKubeSysConfig(processed, f.Filename, kubeSysConfigName)
+			if err != nil {
+				return nil, err
+			}
+		}
+		checksums[f.Filename] = fileChecksum(f)
+		logger.WithFields(logrus.Fields{
+			"source":   fileSources[i],
+			"filename": f.Filename,
+		}).Debug("Added manifest")
+		if err := emit(f); err != nil {
+			return nil, err
+		}
+	}
+
+	summary, err := checksumsFileFor(checksums, dir)
+	if err != nil {
+		return nil, err
+	}
+	converted, err := convertFiles([]*asset.File{summary}, m.Format)
+	if err != nil {
+		return nil, err
+	}
+	logger.WithFields(logrus.Fields{
+		"source":   m.Name(),
+		"filename": converted[0].Filename,
+	}).Debug("Added manifest")
+	if err := emit(converted[0]); err != nil {
+		return nil, err
+	}
+
+	return kubeSysConfig, nil
+}
+
+// checksumsFile returns the checksums file for the files, placed in the
+// manifests directory and serialized in the output format.
+func (m *Manifests) checksumsFile(files []*asset.File) (*asset.File, error) {
+	checksums, err := checksumsFile(files, m.directory())
+	if err != nil {
+		return nil, err
+	}
+	converted, err := convertFiles([]*asset.File{checksums}, m.Format)
+	if err != nil {
+		return nil, err
+	}
+	return converted[0], nil
 }
 
 // Files returns the files generated by the asset.
@@ -142,7 +568,392 @@ func (m *Manifests) Files() []*asset.File {
 	return m.FileList
 }
 
-func (m *Manifests) generateBootKubeManifests(dependencies asset.Parents) []*asset.File {
+// FileMap returns the generated files keyed by filename. Generate never
+// produces two files with the same name, but if FileList holds duplicates
+// the last of them is returned.
+func (m *Manifests) FileMap() map[string]*asset.File {
+	files := make(map[string]*asset.File, len(m.FileList))
+	for _, f := range m.FileList {
+		files[f.Filename] = f
+	}
+	return files
+}
+
+// DeepCopy returns a copy of the asset that shares no state with the
+// original.
+func (m *Manifests) DeepCopy() *Manifests {
+	out := &Manifests{
+		Directory:                m.Directory,
+		Format:                   m.Format,
+		KubeSysConfigName:        m.KubeSysConfigName,
+		KubeSysConfigLabels:      copyStringMap(m.KubeSysConfigLabels),
+		KubeSysConfigAnnotations: copyStringMap(m.KubeSysConfigAnnotations),
+		PostProcess:              m.PostProcess,
+		SealedSecretsKey:         m.SealedSecretsKey,
+		TargetKubernetesVersion:  m.TargetKubernetesVersion,
+		Metrics:                  m.Metrics,
+		PlaceholderTLS:           m.PlaceholderTLS,
+		FilePriority:             m.FilePriority,
+	}
+	if m.RedactedFields != nil {
+		out.RedactedFields = append([]string{}, m.RedactedFields...)
+	}
+	if m.SkippedManifests != nil {
+		out.SkippedManifests = append([]string{}, m.SkippedManifests...)
+	}
+	if m.ExternalPullSecret != nil {
+		ref := *m.ExternalPullSecret
+		out.ExternalPullSecret = &ref
+	}
+	if m.KubeSysConfig != nil {
+		out.KubeSysConfig = m.KubeSysConfig.deepCopy()
+	}
+	if m.FileList != nil {
+		out.FileList = make([]*asset.File, len(m.FileList))
+		for i, f := range m.FileList {
+			if f == nil {
+				continue
+			}
+			out.FileList[i] = &asset.File{
+				Filename: f.Filename,
+				Data:     append([]byte(nil), f.Data...),
+			}
+		}
+	}
+	return out
+}
+
+// ExpectedFilenames returns the names of the manifests that are always
+// generated by the asset, without its dependencies. Manifests that are only
+// generated for some install-configs, such as the cloud provider config,
+// image content source policies, the manifests that require a feature gate
+// or the manifests of in-cluster etcd members, are not included. None of the
+// templates are rendered: the Namespace manifests are found by scanning the
+// bootkube templates with their actions removed.
+func (m *Manifests) ExpectedFilenames() ([]string, error) {
+	return m.expectedFilenames(nil)
+}
+
+// expectedFilenames returns ExpectedFilenames and, when the install-config is
+// set, the names of the bootkube manifests that are generated for it: those
+// of in-cluster etcd members, of the enabled feature gates and the
+// conditional manifests.
+func (m *Manifests) expectedFilenames(ic *types.InstallConfig) ([]string, error) {
+	filenames := []string{
+		kubeSysConfigPath,
+		bootstrapReadinessFilename,
+		checksumsFilename,
+		clusterIngressConfigFile,
+		dnsCfgFilename,
+		noCrdFilename,
+		noCfgFilename,
+		infraCfgFilename,
+		installerProvenanceFilename,
+		proxyCfgFilename,
+		schedulerCfgFilename,
+	}
+	for i, filename := range filenames {
+		filenames[i] = relocatedFilename(filename, m.directory())
+	}
+	skipped := map[string]bool{}
+	for _, name := range m.SkippedManifests {
+		skipped[name] = true
+	}
+	templates := []asset.WritableAsset{}
+	for _, a := range bootkubeTemplates() {
+		// The manifests that require a feature gate are not always generated.
+		if _, ok := a.(FeatureGated); ok && (ic == nil || !featureGateEnabled(a, ic)) {
+			continue
+		}
+		templates = append(templates, a)
+	}
+	inClusterEtcd := false
+	if ic != nil {
+		inClusterEtcd = ic.ExternalEtcd == nil
+		for _, a := range conditionalBootkubeTemplates() {
+			if conditionalBootkubeTemplateEnabled(a, ic) {
+				templates = append(templates, a)
+			}
+		}
+	}
+	scanned := []*asset.File{}
+	for _, a := range templates {
+		if err := a.Generate(asset.Parents{}); err != nil {
+			return nil, errors.Wrapf(err, "failed to generate %s", a.Name())
+		}
+		for _, f := range a.Files() {
+			filename := bootkubeManifestFilename(m.directory(), f)
+			if base := filepath.Base(filename); !skipped[base] && (inClusterEtcd || !inClusterEtcdManifests[base]) {
+				filenames = append(filenames, filename)
+				scanned = append(scanned, withoutTemplateActions(f))
+			}
+		}
+	}
+
+	provenanceFile, err := installerProvenanceFile(m.Dependencies())
+	if err != nil {
+		return nil, err
+	}
+	namespaces, err := namespaceFiles(append(scanned, provenanceFile), m.directory())
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range namespaces {
+		filenames = append(filenames, f.Filename)
+	}
+	sort.Strings(filenames)
+	return filenames, nil
+}
+
+// FilesMatching returns the generated files whose names, relative to the
+// manifests directory, match the given glob pattern. The pattern uses the
+// same syntax as FetchByPattern.
+func (m *Manifests) FilesMatching(pattern string) ([]*asset.File, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, errors.Wrapf(err, "invalid pattern %q", pattern)
+	}
+	files := []*asset.File{}
+	for _, f := range m.FileList {
+		name, err := filepath.Rel(m.directory(), f.Filename)
+		if err != nil {
+			return nil, err
+		}
+		matched, err := filepath.Match(pattern, name)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %q", pattern)
+		}
+		if matched {
+			files = append(files, f)
+		}
+	}
+	return files, nil
+}
+
+// Validate checks the invariants of the generated manifests: every file has
+// data and a name in the manifests directory, and the kube-system
+// cluster-config configmap is present and parses.
+func (m *Manifests) Validate() error {
+	dir := m.directory()
+	kubeSysConfigFilename := relocatedFilename(kubeSysConfigPath, dir)
+	var kubeSysConfigFile *asset.File
+	for i, f := range m.FileList {
+		if f == nil {
+			return errors.Errorf("file %d is nil", i)
+		}
+		if f.Filename == "" {
+			return errors.Errorf("file %d has no filename", i)
+		}
+		if filepath.Dir(f.Filename) != dir {
+			return errors.Errorf("%s is not in the %s directory", f.Filename, dir)
+		}
+		if f.Data == nil {
+			return errors.Errorf("%s has no data", f.Filename)
+		}
+		if f.Filename == kubeSysConfigFilename {
+			kubeSysConfigFile = f
+		}
+	}
+
+	if kubeSysConfigFile == nil {
+		return errors.Errorf("%s is missing", kubeSysConfigFilename)
+	}
+	if err := yaml.Unmarshal(kubeSysConfigFile.Data, &configurationObject{}); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal %s", kubeSysConfigFilename)
+	}
+
+	if m.KubeSysConfig == nil {
+		return errors.New("the kube-system cluster-config configmap is not set")
+	}
+	if ns := m.KubeSysConfig.Metadata.Namespace; ns != "kube-system" {
+		return errors.Errorf("the cluster-config configmap is in the %q namespace instead of kube-system", ns)
+	}
+	return nil
+}
+
+// generateBootKubeManifests renders the bootkube templates, recording the
+// template asset each manifest was rendered from in sources. When redact is
+// set, the templates are rendered without any key material.
+func (m *Manifests) generateBootKubeManifests(dependencies asset.Parents, dir string, sources map[*asset.File]string, redact bool) ([]*asset.File, error) {
+	etcdPodDisruptionBudget := &bootkube.EtcdPodDisruptionBudget{}
+	etcdBackupClientSecret := &bootkube.EtcdBackupClientSecret{}
+	etcdBackupCronJob := &bootkube.EtcdBackupCronJob{}
+	etcdBackupServiceAccount := &bootkube.EtcdBackupServiceAccount{}
+	etcdBackupRole := &bootkube.EtcdBackupRole{}
+	etcdBackupRoleBinding := &bootkube.EtcdBackupRoleBinding{}
+	alertmanagerMainSecret := &bootkube.AlertmanagerMainSecret{}
+	etcdServiceMonitor := &bootkube.EtcdServiceMonitor{}
+	etcdMonitoringClientSecret := &bootkube.EtcdMonitoringClientSecret{}
+	etcdMonitoringServingCAConfigMap := &bootkube.EtcdMonitoringServingCAConfigMap{}
+	dependencies.Get(etcdPodDisruptionBudget, etcdBackupClientSecret, etcdBackupCronJob, etcdBackupServiceAccount, etcdBackupRole, etcdBackupRoleBinding, alertmanagerMainSecret, etcdServiceMonitor, etcdMonitoringClientSecret, etcdMonitoringServingCAConfigMap)
+
+	templateData, err := m.bootkubeTemplateData(dependencies, redact)
+	if err != nil {
+		return nil, err
+	}
+
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+	externalEtcd := installConfig.Config.ExternalEtcd != nil
+
+	templates := []asset.WritableAsset{}
+	gated := []asset.WritableAsset{}
+	for _, a := range bootkubeTemplates() {
+		if featureGateEnabled(a, installConfig.Config) {
+			templates = append(templates, a)
+		} else {
+			gated = append(gated, a)
+		}
+	}
+	// Conditional manifests can be skipped even when they are not generated.
+	conditional := []asset.WritableAsset{etcdPodDisruptionBudget, etcdBackupClientSecret, etcdBackupCronJob, etcdBackupServiceAccount, etcdBackupRole, etcdBackupRoleBinding, alertmanagerMainSecret, etcdServiceMonitor, etcdMonitoringClientSecret, etcdMonitoringServingCAConfigMap}
+	for _, a := range conditional {
+		if conditionalBootkubeTemplateEnabled(a, installConfig.Config) {
+			templates = append(templates, a)
+		}
+	}
+
+	for _, a := range append(templates, gated...) {
+		dependencies.Get(a)
+	}
+	skippable := append(conditional, append(templates, gated...)...)
+	skipped, err := m.skippedBootkubeManifests(skippable)
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := []bootkubeRenderJob{}
+	for _, a := range templates {
+		for _, f := range a.Files() {
+			filename := bootkubeManifestFilename(dir, f)
+			if skipped[filepath.Base(filename)] || (externalEtcd && inClusterEtcdManifests[filepath.Base(filename)]) {
+				continue
+			}
+			jobs = append(jobs, bootkubeRenderJob{
+				source:   a.Name(),
+				template: f,
+				filename: filename,
+			})
+		}
+	}
+
+	files, err := renderBootkubeTemplates(jobs, templateData, runtime.GOMAXPROCS(0))
+	if err != nil {
+		return nil, err
+	}
+	for i, file := range files {
+		sources[file] = jobs[i].source
+	}
+	return files, nil
+}
+
+// bootkubeTemplateData returns the data the bootkube templates are rendered
+// with, as selected by the options of the asset. When redact is set, the
+// data holds no key material.
+func (m *Manifests) bootkubeTemplateData(dependencies asset.Parents, redact bool) (*BootkubeTemplateData, error) {
+	templateData, err := newBootkubeTemplateData(dependencies, m.PlaceholderTLS)
+	if err != nil {
+		return nil, err
+	}
+	if redact {
+		templateData = redactedTemplateData(*templateData)
+	}
+	templateData.APIVersions, err = kubernetesAPIVersions(m.TargetKubernetesVersion)
+	if err != nil {
+		return nil, err
+	}
+	if m.ExternalPullSecret != nil {
+		if err := m.ExternalPullSecret.validate(); err != nil {
+			return nil, err
+		}
+		ref := *m.ExternalPullSecret
+		templateData.ExternalPullSecret = &ref
+		templateData.PullSecretBase64 = ""
+	}
+	return templateData, nil
+}
+
+// bootkubeRenderJob is a bootkube template waiting to be rendered.
+type bootkubeRenderJob struct {
+	source   string
+	template *asset.File
+	filename string
+}
+
+// renderBootkubeTemplates renders the templates with up to workers running
+// at once. The rendered files are returned in the order of the jobs and, if
+// several templates fail, the error of the first of them is returned, so the
+// result does not depend on scheduling.
+func renderBootkubeTemplates(jobs []bootkubeRenderJob, templateData *BootkubeTemplateData, workers int) ([]*asset.File, error) {
+	if workers > len(jobs) {
+		workers = len(jobs)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	// The template data is digested once for all of the templates.
+	render := applyTemplateDataDelims
+	if templateCache.enabled() {
+		if digest, ok := templateDataDigest(templateData); ok {
+			render = func(data []byte, templateData interface{}, left, right string) ([]byte, error) {
+				return renderCachedTemplate(data, templateData, left, right, digest)
+			}
+		}
+	}
+
+	files := make([]*asset.File, len(jobs))
+	errs := make([]error, len(jobs))
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				job := jobs[i]
+				left, right := templateDelimiters(job.template.Filename)
+				data, err := render(job.template.Data, templateData, left, right)
+				if err != nil {
+					errs[i] = &ManifestGenerationError{
+						Asset:    job.source,
+						Filename: job.filename,
+						Err:      errors.Wrapf(err, "failed to render %s from %s", job.filename, job.template.Filename),
+					}
+					continue
+				}
+				files[i] = &asset.File{
+					Filename: job.filename,
+					Data:     data,
+				}
+			}
+		}()
+	}
+	for i := range jobs {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return files, nil
+}
+
+// NewBootkubeTemplateData returns the data used to render the bootkube
+// templates. The parents must include the ClusterID, InstallConfig and
+// Networking assets and the etcd, MCS and root CA TLS assets, as they do for
+// the Manifests asset.
+func NewBootkubeTemplateData(dependencies asset.Parents) (*BootkubeTemplateData, error) {
+	return newBootkubeTemplateData(dependencies, false)
+}
+
+// newBootkubeTemplateData returns the data used to render the bootkube
+// templates. With placeholderTLS, the TLS assets are neither read from the
+// parents nor verified, and placeholders are used in their stead.
+func newBootkubeTemplateData(dependencies asset.Parents, placeholderTLS bool) (*BootkubeTemplateData, error) {
 	clusterID := &installconfig.ClusterID{}
 	installConfig := &installconfig.InstallConfig{}
 	mcsCertKey := &tls.MCSCertKey{}
@@ -153,87 +964,399 @@ func (m *Manifests) generateBootKubeManifests(dependencies asset.Parents) []*ass
 	etcdSignerCertKey := &tls.EtcdSignerCertKey{}
 	etcdCABundle := &tls.EtcdCABundle{}
 	etcdSignerClientCertKey := &tls.EtcdSignerClientCertKey{}
-	dependencies.Get(
-		clusterID,
-		installConfig,
-		etcdSignerCertKey,
-		etcdCABundle,
-		etcdSignerClientCertKey,
-		etcdMetricCABundle,
-		etcdMetricSignerClientCertKey,
-		etcdMetricSignerCertKey,
-		mcsCertKey,
-		rootCA,
-	)
-
-	etcdEndpointHostnames := make([]string, *installConfig.Config.ControlPlane.Replicas)
-	for i := range etcdEndpointHostnames {
-		etcdEndpointHostnames[i] = fmt.Sprintf("etcd-%d", i)
-	}
-
-	templateData := &bootkubeTemplateData{
-		CVOClusterID:               clusterID.UUID,
-		EtcdCaBundle:               string(etcdCABundle.Cert()),
-		EtcdEndpointDNSSuffix:      installConfig.Config.ClusterDomain(),
-		EtcdEndpointHostnames:      etcdEndpointHostnames,
-		EtcdMetricCaCert:           string(etcdMetricCABundle.Cert()),
-		EtcdMetricSignerCert:       base64.StdEncoding.EncodeToString(etcdMetricSignerCertKey.Cert()),
-		EtcdMetricSignerClientCert: base64.StdEncoding.EncodeToString(etcdMetricSignerClientCertKey.Cert()),
-		EtcdMetricSignerClientKey:  base64.StdEncoding.EncodeToString(etcdMetricSignerClientCertKey.Key()),
-		EtcdMetricSignerKey:        base64.StdEncoding.EncodeToString(etcdMetricSignerCertKey.Key()),
-		EtcdSignerCert:             base64.StdEncoding.EncodeToString(etcdSignerCertKey.Cert()),
-		EtcdSignerClientCert:       base64.StdEncoding.EncodeToString(etcdSignerClientCertKey.Cert()),
-		EtcdSignerClientKey:        base64.StdEncoding.EncodeToString(etcdSignerClientCertKey.Key()),
-		EtcdSignerKey:              base64.StdEncoding.EncodeToString(etcdSignerCertKey.Key()),
-		McsTLSCert:                 base64.StdEncoding.EncodeToString(mcsCertKey.Cert()),
-		McsTLSKey:                  base64.StdEncoding.EncodeToString(mcsCertKey.Key()),
-		PullSecretBase64:           base64.StdEncoding.EncodeToString([]byte(installConfig.Config.PullSecret)),
-		RootCaCert:                 string(rootCA.Cert()),
+	network := &Networking{}
+	dependencies.Get(clusterID, installConfig, network)
+	if placeholderTLS {
+		placeholderCertKey(&etcdSignerCertKey.CertKey, "etcd-signer")
+		placeholderCertBundle(&etcdCABundle.CertBundle, "etcd-ca-bundle")
+		placeholderCertKey(&etcdSignerClientCertKey.CertKey, "etcd-signer-client")
+		placeholderCertBundle(&etcdMetricCABundle.CertBundle, "etcd-metric-ca-bundle")
+		placeholderCertKey(&etcdMetricSignerClientCertKey.CertKey, "etcd-metric-signer-client")
+		placeholderCertKey(&etcdMetricSignerCertKey.CertKey, "etcd-metric-signer")
+		placeholderCertKey(&mcsCertKey.CertKey, "machine-config-server")
+		placeholderCertKey(&rootCA.CertKey, "root-ca")
+	} else {
+		dependencies.Get(
+			etcdSignerCertKey,
+			etcdCABundle,
+			etcdSignerClientCertKey,
+			etcdMetricCABundle,
+			etcdMetricSignerClientCertKey,
+			etcdMetricSignerCertKey,
+			mcsCertKey,
+			rootCA,
+		)
 	}
 
-	files := []*asset.File{}
-	for _, a := range []asset.WritableAsset{
-		&bootkube.CVOOverrides{},
-		&bootkube.EtcdCAConfigMap{},
-		&bootkube.EtcdClientSecret{},
-		&bootkube.EtcdHostServiceEndpoints{},
-		&bootkube.EtcdHostService{},
-		&bootkube.EtcdMetricClientSecret{},
-		&bootkube.EtcdMetricSignerSecret{},
-		&bootkube.EtcdMetricServingCAConfigMap{},
-		&bootkube.EtcdNamespace{},
-		&bootkube.EtcdService{},
-		&bootkube.EtcdServingCAConfigMap{},
-		&bootkube.EtcdSignerSecret{},
-		&bootkube.KubeCloudConfig{},
-		&bootkube.KubeSystemConfigmapRootCA{},
-		&bootkube.MachineConfigServerTLSSecret{},
-		&bootkube.OpenshiftConfigSecretPullSecret{},
-		&bootkube.OpenshiftMachineConfigOperator{},
-	} {
-		dependencies.Get(a)
-		for _, f := range a.Files() {
-			files = append(files, &asset.File{
-				Filename: filepath.Join(manifestDir, strings.TrimSuffix(filepath.Base(f.Filename), ".template")),
-				Data:     applyTemplateData(f.Data, templateData),
-			})
+	err := validateBase64Inputs([]base64Input{
+		{field: "EtcdMetricSignerCert", data: etcdMetricSignerCertKey.Cert()},
+		{field: "EtcdMetricSignerClientCert", data: etcdMetricSignerClientCertKey.Cert()},
+		{field: "EtcdMetricSignerClientKey", data: etcdMetricSignerClientCertKey.Key()},
+		{field: "EtcdMetricSignerKey", data: etcdMetricSignerCertKey.Key()},
+		{field: "EtcdSignerCert", data: etcdSignerCertKey.Cert()},
+		{field: "EtcdSignerClientCert", data: etcdSignerClientCertKey.Cert()},
+		{field: "EtcdSignerClientKey", data: etcdSignerClientCertKey.Key()},
+		{field: "EtcdSignerKey", data: etcdSignerCertKey.Key()},
+		{field: "McsTLSCert", data: mcsCertKey.Cert()},
+		{field: "McsTLSKey", data: mcsCertKey.Key()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// A truncated pull secret would otherwise only fail once nodes pull images.
+	if err := validate.ImagePullSecret(installConfig.Config.PullSecret); err != nil {
+		return nil, errors.Wrap(err, "invalid pull secret")
+	}
+	// The placeholders are not certificates, so there is nothing to verify.
+	if !placeholderTLS {
+		err = verifyCertChains([]certChainCheck{
+			{name: "etcd-signer", cert: etcdSignerCertKey.Cert(), bundle: "etcd-ca-bundle", caBundle: etcdCABundle.Cert()},
+			{name: "etcd-client", cert: etcdSignerClientCertKey.Cert(), bundle: "etcd-ca-bundle", caBundle: etcdCABundle.Cert()},
+			{name: "etcd-metric-signer", cert: etcdMetricSignerCertKey.Cert(), bundle: "etcd-metric-ca-bundle", caBundle: etcdMetricCABundle.Cert()},
+			{name: "etcd-metric-signer-client", cert: etcdMetricSignerClientCertKey.Cert(), bundle: "etcd-metric-ca-bundle", caBundle: etcdMetricCABundle.Cert()},
+		})
+		if err != nil {
+			return nil, err
 		}
 	}
-	return files
+
+	if installConfig.Config.FIPS && !placeholderTLS {
+		err := verifyFIPSCertificates([]fipsCertificate{
+			{name: "root-ca", cert: rootCA.Cert()},
+			{name: "etcd-signer", cert: etcdSignerCertKey.Cert()},
+			{name: "etcd-client", cert: etcdSignerClientCertKey.Cert()},
+			{name: "etcd-metric-signer", cert: etcdMetricSignerCertKey.Cert()},
+			{name: "etcd-metric-signer-client", cert: etcdMetricSignerClientCertKey.Cert()},
+			{name: "machine-config-server", cert: mcsCertKey.Cert()},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	pullSecret, err := MirrorPullSecret(installConfig.Config.PullSecret, installConfig.Config.ImageContentSources)
+	if err != nil {
+		return nil, err
+	}
+
+	etcdDiscoveryDomain := installConfig.Config.EtcdDiscoveryDomain()
+	if err := validateEtcdDiscoveryDomain(etcdDiscoveryDomain); err != nil {
+		return nil, err
+	}
+
+	hostnames := EtcdEndpointHostnames(installConfig.Config)
+	etcdCaBundle := string(etcdCABundle.Cert())
+	if installConfig.Config.ExternalEtcd != nil {
+		etcdCaBundle = installConfig.Config.ExternalEtcd.CABundle
+	}
+	apiVersions, err := kubernetesAPIVersions("")
+	if err != nil {
+		return nil, err
+	}
+	var etcdBackupImage, etcdBackupSchedule string
+	if backup := installConfig.Config.EtcdBackup; backup != nil {
+		etcdBackupImage, etcdBackupSchedule = backup.Image, backup.Schedule
+	}
+	var alertmanagerConfig string
+	if receiver := installConfig.Config.AlertReceiver; receiver != nil {
+		config, err := alertmanagerConfigYAML(receiver)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to generate the Alertmanager configuration")
+		}
+		alertmanagerConfig = base64.StdEncoding.EncodeToString(config)
+	}
+	return &BootkubeTemplateData{
+		APIVersions:                 apiVersions,
+		AlertmanagerConfigBase64:    alertmanagerConfig,
+		ControlPlaneTopology:        controlPlaneTopology(len(hostnames)),
+		CVOClusterID:                clusterID.UUID,
+		CVOOverrides:                mergeCVOOverrides(requiredCVOOverrides, installConfig.Config.ClusterVersionOverrides),
+		EtcdBackupImage:             etcdBackupImage,
+		EtcdBackupSchedule:          etcdBackupSchedule,
+		EtcdCaBundle:                etcdCaBundle,
+		EtcdClusterMonitoring:       installConfig.Config.ExternalEtcd == nil && installConfig.Config.ClusterMonitoring,
+		EtcdEndpointDNSSuffix:       etcdDiscoveryDomain,
+		EtcdEndpointAddressPrefixes: etcdEndpointAddressPrefixes(network.Config),
+		EtcdEndpointHostnames:       hostnames,
+		EtcdMetricCaCert:            string(etcdMetricCABundle.Cert()),
+		EtcdMetricSignerCert:        base64.StdEncoding.EncodeToString(etcdMetricSignerCertKey.Cert()),
+		EtcdMetricSignerClientCert:  base64.StdEncoding.EncodeToString(etcdMetricSignerClientCertKey.Cert()),
+		EtcdMetricSignerClientKey:   base64.StdEncoding.EncodeToString(etcdMetricSignerClientCertKey.Key()),
+		EtcdMetricSignerKey:         base64.StdEncoding.EncodeToString(etcdMetricSignerCertKey.Key()),
+		EtcdMinAvailable:            len(hostnames)/2 + 1,
+		EtcdSignerCert:              base64.StdEncoding.EncodeToString(etcdSignerCertKey.Cert()),
+		EtcdSignerClientCert:        base64.StdEncoding.EncodeToString(etcdSignerClientCertKey.Cert()),
+		EtcdSignerClientKey:         base64.StdEncoding.EncodeToString(etcdSignerClientCertKey.Key()),
+		EtcdSignerKey:               base64.StdEncoding.EncodeToString(etcdSignerCertKey.Key()),
+		McsTLSCert:                  base64.StdEncoding.EncodeToString(mcsCertKey.Cert()),
+		McsTLSKey:                   base64.StdEncoding.EncodeToString(mcsCertKey.Key()),
+		PullSecretBase64:            base64.StdEncoding.EncodeToString([]byte(pullSecret)),
+		RootCaCert:                  string(rootCA.Cert()),
+	}, nil
+}
+
+// RenderBootkubeTemplate renders a bootkube template with the given data,
+// using the same template functions as the Manifests asset.
+func RenderBootkubeTemplate(tmpl []byte, data *BootkubeTemplateData) ([]byte, error) {
+	return applyTemplateData(tmpl, data)
+}
+
+// checkDuplicateFilenames returns an error if two of the files share a name,
+// since only one of them would survive being written to disk.
+func checkDuplicateFilenames(files []*asset.File, sources map[*asset.File]string) error {
+	seen := make(map[string]*asset.File, len(files))
+	for _, f := range files {
+		if other, ok := seen[f.Filename]; ok {
+			return errors.Errorf("%s is generated by both %s and %s", f.Filename, sources[other], sources[f])
+		}
+		seen[f.Filename] = f
+	}
+	return nil
 }
 
-func applyTemplateData(data []byte, templateData interface{}) []byte {
-	template := template.Must(template.New("template").Funcs(customTmplFuncs).Parse(string(data)))
+// bootkubeManifestFilename returns the name of the manifest rendered into dir
+// from the given bootkube template file.
+func bootkubeManifestFilename(dir string, f *asset.File) string {
+	return filepath.Join(dir, trimTemplateSuffix(filepath.Base(f.Filename)))
+}
+
+// directory returns the directory the manifests are generated into.
+func (m *Manifests) directory() string {
+	if m.Directory != "" {
+		return m.Directory
+	}
+	return manifestDir
+}
+
+// kubeSysConfigName returns the name of the kube-system configmap holding the
+// install-config.
+func (m *Manifests) kubeSysConfigName() string {
+	if m.KubeSysConfigName != "" {
+		return m.KubeSysConfigName
+	}
+	return defaultKubeSysConfigName
+}
+
+// relocatedFilename moves a filename from the default manifests directory
+// into dir.
+func relocatedFilename(filename, dir string) string {
+	return filepath.Join(dir, filepath.Base(filename))
+}
+
+// relocatedFile returns the file moved into dir. The file is copied rather
+// than modified, since it may be shared with the asset that generated it.
+func relocatedFile(f *asset.File, dir string) *asset.File {
+	if dir == manifestDir {
+		return f
+	}
+	return &asset.File{
+		Filename: relocatedFilename(f.Filename, dir),
+		Data:     f.Data,
+	}
+}
+
+// EtcdEndpointHostnames returns the hostnames of the external etcd members
+// when they are configured, and otherwise the etcd member hostnames of the
+// control plane, assuming the default of three replicas when the count is
+// not set.
+func EtcdEndpointHostnames(ic *types.InstallConfig) []string {
+	if ic.ExternalEtcd != nil {
+		return append([]string(nil), ic.ExternalEtcd.Hostnames...)
+	}
+
+	replicas := int64(defaultControlPlaneReplicas)
+	if ic.ControlPlane != nil && ic.ControlPlane.Replicas != nil {
+		replicas = *ic.ControlPlane.Replicas
+	} else {
+		logrus.Warnf("Control plane replicas not set, assuming %d etcd members", replicas)
+	}
+
+	hostnames := make([]string, replicas)
+	for i := range hostnames {
+		hostnames[i] = ic.EtcdMemberHostname(i)
+	}
+	return hostnames
+}
+
+// etcdEndpointAddressPrefixes returns the prefixes of the placeholder
+// addresses used for the etcd endpoints, one for each address family
+// configured for the cluster networks. The prefixes are in the documentation
+// ranges and are completed with the member index. Clusters with no parsable
+// networks are assumed to be IPv4 only.
+func etcdEndpointAddressPrefixes(network *configv1.Network) []string {
+	var ipv4, ipv6 bool
+	if network != nil {
+		cidrs := append([]string{}, network.Spec.ServiceNetwork...)
+		for _, cn := range network.Spec.ClusterNetwork {
+			cidrs = append(cidrs, cn.CIDR)
+		}
+		for _, cidr := range cidrs {
+			ip, _, err := net.ParseCIDR(cidr)
+			if err != nil {
+				continue
+			}
+			if ip.To4() != nil {
+				ipv4 = true
+			} else {
+				ipv6 = true
+			}
+		}
+	}
+
+	prefixes := []string{}
+	if ipv4 || !ipv6 {
+		prefixes = append(prefixes, "192.0.2.")
+	}
+	if ipv6 {
+		prefixes = append(prefixes, "2001:db8::")
+	}
+	return prefixes
+}
+
+func jsonBase64(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// defaultValue returns the given value, or def if it is missing or empty,
+// following the semantics of the Sprig default function. Empty values are
+// nil, false, zero numbers and empty strings, slices and maps.
+func defaultValue(def interface{}, given ...interface{}) interface{} {
+	if len(given) == 0 || isEmptyValue(given[0]) {
+		return def
+	}
+	return given[0]
+}
+
+func isEmptyValue(v interface{}) bool {
+	value := reflect.ValueOf(v)
+	if !value.IsValid() {
+		return true
+	}
+	switch value.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return value.Len() == 0
+	case reflect.Bool:
+		return !value.Bool()
+	case reflect.Complex64, reflect.Complex128:
+		return value.Complex() == 0
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return value.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return value.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return value.Float() == 0
+	case reflect.Interface, reflect.Ptr:
+		return value.IsNil()
+	case reflect.Struct:
+		return false
+	default:
+		return value.IsZero()
+	}
+}
+
+// dataURI returns a base64 data URI with the given MIME type for the payload,
+// which is either a byte slice or an already base64-encoded string.
+func dataURI(mimeType string, payload interface{}) (string, error) {
+	var encoded string
+	switch p := payload.(type) {
+	case []byte:
+		encoded = base64.StdEncoding.EncodeToString(p)
+	case string:
+		if _, err := base64.StdEncoding.DecodeString(p); err != nil {
+			return "", errors.Wrap(err, "dataURI payload is not base64-encoded")
+		}
+		encoded = p
+	default:
+		return "", errors.Errorf("unsupported dataURI payload type %T", payload)
+	}
+	return fmt.Sprintf("data:%s;base64,%s", mimeType, encoded), nil
+}
+
+// templateSuffixes are the filename suffixes of the templates and the action
+// delimiters used to render them. Templates whose output must contain
+// literal {{ }} sequences, such as alerting rules, use the [[ ]] delimiters.
+var templateSuffixes = []struct {
+	suffix      string
+	left, right string
+}{
+	{suffix: ".template", left: "{{", right: "}}"},
+	{suffix: ".bracket-template", left: "[[", right: "]]"},
+}
+
+// templateDelimiters returns the action delimiters for rendering the named
+// template, defaulting to {{ }}.
+func templateDelimiters(filename string) (string, string) {
+	for _, s := range templateSuffixes {
+		if strings.HasSuffix(filename, s.suffix) {
+			return s.left, s.right
+		}
+	}
+	return "{{", "}}"
+}
+
+// trimTemplateSuffix returns the filename without its template suffix.
+func trimTemplateSuffix(filename string) string {
+	for _, s := range templateSuffixes {
+		if strings.HasSuffix(filename, s.suffix) {
+			return strings.TrimSuffix(filename, s.suffix)
+		}
+	}
+	return filename
+}
+
+func applyTemplateData(data []byte, templateData interface{}) ([]byte, error) {
+	return applyTemplateDataDelims(data, templateData, "{{", "}}")
+}
+
+// applyTemplateDataDelims is applyTemplateData with the given action
+// delimiters. Renderings are reused from the template cache when it is
+// enabled.
+func applyTemplateDataDelims(data []byte, templateData interface{}, left, right string) ([]byte, error) {
+	if templateCache.enabled() {
+		if digest, ok := templateDataDigest(templateData); ok {
+			return renderCachedTemplate(data, templateData, left, right, digest)
+		}
+	}
+	return renderTemplate(data, templateData, left, right)
+}
+
+// renderCachedTemplate renders the template through the template cache. The
+// digest is the templateDataDigest of the template data, which callers
+// rendering many templates with the same data compute once.
+func renderCachedTemplate(data []byte, templateData interface{}, left, right string, digest [sha256.Size]byte) ([]byte, error) {
+	key := renderCacheKey(data, left, right, digest)
+	if rendered, ok := templateCache.get(key); ok {
+		return rendered, nil
+	}
+	rendered, err := renderTemplate(data, templateData, left, right)
+	if err != nil {
+		return nil, err
+	}
+	templateCache.add(key, rendered)
+	return rendered, nil
+}
+
+func renderTemplate(data []byte, templateData interface{}, left, right string) ([]byte, error) {
+	template, err := template.New("template").Delims(left, right).Funcs(customTmplFuncs).Option("missingkey=error").Parse(string(data))
+	if err != nil {
+		return nil, err
+	}
 	buf := &bytes.Buffer{}
 	if err := template.Execute(buf, templateData); err != nil {
-		panic(err)
+		return nil, err
 	}
-	return buf.Bytes()
+	return buf.Bytes(), nil
 }
 
-// Load returns the manifests asset from disk.
+// Load returns the manifests asset from disk. Manifests generated with
+// placeholder TLS assets are rejected.
 func (m *Manifests) Load(f asset.FileFetcher) (bool, error) {
-	fileList, err := f.FetchByPattern(filepath.Join(manifestDir, "*"))
+	fileList, err := f.FetchByPattern(filepath.Join(m.directory(), "*"))
 	if err != nil {
 		return false, err
 	}
@@ -241,30 +1364,91 @@ func (m *Manifests) Load(f asset.FileFetcher) (bool, error) {
 		return false, nil
 	}
 
-	kubeSysConfig := &configurationObject{}
-	var found bool
-	for _, file := range fileList {
-		if file.Filename == kubeSysConfigPath {
-			if err := yaml.Unmarshal(file.Data, kubeSysConfig); err != nil {
-				return false, errors.Wrapf(err, "failed to unmarshal %s", kubeSysConfigPath)
-			}
-			found = true
-		}
+	kubeSysConfig, err := findKubeSysConfig(fileList, relocatedFilename(kubeSysConfigPath, m.directory()), m.kubeSysConfigName())
+	if err != nil {
+		return false, err
 	}
-
-	if !found {
+	if kubeSysConfig == nil {
 		return false, nil
-
+	}
+	if name := placeholderTLSFile(fileList); name != "" {
+		return false, errors.Errorf("%s was generated with placeholder TLS assets, so the manifests cannot be used to install a cluster", name)
 	}
 
 	m.FileList, m.KubeSysConfig = fileList, kubeSysConfig
 
-	asset.SortFiles(m.FileList)
+	m.sortFiles(m.FileList)
 
 	return true, nil
 }
 
-func redactedInstallConfig(config types.InstallConfig) ([]byte, error) {
+// findKubeSysConfig returns the kube-system configmap with the given name from
+// the files, or nil if there is none. The file with the canonical filename is
+// used when present. Otherwise, manifests written by installers that named
+// the file differently are recognized by the configmap's metadata.
+func findKubeSysConfig(files []*asset.File, filename, name string) (*configurationObject, error) {
+	for _, file := range files {
+		if file.Filename == filename {
+			kubeSysConfig := &configurationObject{}
+			if err := yaml.Unmarshal(file.Data, kubeSysConfig); err != nil {
+				return nil, errors.Wrapf(err, "failed to unmarshal %s", filename)
+			}
+			return kubeSysConfig, nil
+		}
+	}
+
+	for _, file := range files {
+		obj := &configurationObject{}
+		// Files that are not single objects cannot be the configmap.
+		if err := yaml.Unmarshal(file.Data, obj); err != nil {
+			continue
+		}
+		if obj.Kind == "ConfigMap" && obj.Metadata.Namespace == "kube-system" && obj.Metadata.Name == name {
+			return obj, nil
+		}
+	}
+	return nil, nil
+}
+
+// redactedTemplateData returns a copy of the bootkube template data with the
+// keys, certificates and pull secret blanked.
+func redactedTemplateData(data BootkubeTemplateData) *BootkubeTemplateData {
+	data.AlertmanagerConfigBase64 = ""
+	data.EtcdCaBundle = ""
+	data.EtcdMetricCaCert = ""
+	data.EtcdMetricSignerCert = ""
+	data.EtcdMetricSignerClientCert = ""
+	data.EtcdMetricSignerClientKey = ""
+	data.EtcdMetricSignerKey = ""
+	data.EtcdSignerCert = ""
+	data.EtcdSignerClientCert = ""
+	data.EtcdSignerClientKey = ""
+	data.EtcdSignerKey = ""
+	data.McsTLSCert = ""
+	data.McsTLSKey = ""
+	data.PullSecretBase64 = ""
+	data.RootCaCert = ""
+	return &data
+}
+
+// redactedURL returns the URL without any credentials. URLs that do not parse
+// are blanked, since their credentials cannot be located.
+func redactedURL(rawURL string) string {
+	if rawURL == "" {
+		return ""
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	return u.String()
+}
+
+// redactedInstallConfig returns the install-config without its credentials.
+// The extra field selectors, described by redactFields, name additional
+// fields to blank.
+func redactedInstallConfig(config types.InstallConfig, extraFields []string) ([]byte, error) {
 	config.PullSecret = ""
 	if config.Platform.VSphere != nil {
 		p := *config.Platform.VSphere
@@ -272,10 +1456,210 @@ func redactedInstallConfig(config types.InstallConfig) ([]byte, error) {
 		p.Password = ""
 		config.Platform.VSphere = &p
 	}
-	return yaml.Marshal(config)
+	if config.Platform.BareMetal != nil {
+		p := *config.Platform.BareMetal
+		if p.Hosts != nil {
+			p.Hosts = make([]*baremetal.Host, len(config.Platform.BareMetal.Hosts))
+			for i, h := range config.Platform.BareMetal.Hosts {
+				if h == nil {
+					continue
+				}
+				host := *h
+				host.BMC.Username = ""
+				host.BMC.Password = ""
+				p.Hosts[i] = &host
+			}
+		}
+		config.Platform.BareMetal = &p
+	}
+	if config.AlertReceiver != nil {
+		r := *config.AlertReceiver
+		if r.Slack != nil {
+			// The webhook URL is the credential of a Slack webhook.
+			s := *r.Slack
+			s.APIURL = ""
+			r.Slack = &s
+		}
+		if r.Email != nil {
+			e := *r.Email
+			e.AuthPassword = ""
+			r.Email = &e
+		}
+		config.AlertReceiver = &r
+	}
+	if config.Proxy != nil {
+		p := *config.Proxy
+		p.HTTPProxy = redactedURL(p.HTTPProxy)
+		p.HTTPSProxy = redactedURL(p.HTTPSProxy)
+		config.Proxy = &p
+	}
+	data, err := yaml.Marshal(config)
+	if err != nil {
+		return nil, err
+	}
+	if len(extraFields) > 0 {
+		data, err = redactFields(data, extraFields)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// Consumers of the configmap must be able to parse the redacted config.
+	redacted := &types.InstallConfig{}
+	if err := yaml.Unmarshal(data, redacted); err != nil {
+		return nil, errors.Wrap(err, "redacted install-config does not unmarshal")
+	}
+	// The trust bundle is not secret, and disconnected clusters trust their
+	// mirrors through it, so it must survive the YAML round trip byte for
+	// byte unless a selector blanked it.
+	if bundle := redacted.AdditionalTrustBundle; bundle != "" && bundle != config.AdditionalTrustBundle {
+		return nil, errors.New("redacted install-config does not preserve the additional trust bundle")
+	}
+	return data, nil
+}
+
+// join joins the items with the separator. The separator comes first so
+// that lists can be piped into it.
+func join(sep string, items []string) string {
+	return strings.Join(items, sep)
+}
+
+// trimPrefix returns s without the prefix. The string comes last so that it
+// can be piped into it, as with sprig.
+func trimPrefix(prefix, s string) string {
+	return strings.TrimPrefix(s, prefix)
+}
+
+// trimSuffix returns s without the suffix.
+func trimSuffix(suffix, s string) string {
+	return strings.TrimSuffix(s, suffix)
+}
+
+// replace returns s with every occurrence of old replaced by new.
+func replace(old, new, s string) string {
+	return strings.Replace(s, old, new, -1)
+}
+
+// lookup returns the value of the key in the map, or the fallback if the
+// map has no such key.
+func lookup(m map[string]string, key, fallback string) string {
+	if v, ok := m[key]; ok {
+		return v
+	}
+	return fallback
+}
+
+// mod returns the remainder of dividing i by j. It fails for a zero j rather
+// than panicking in the middle of rendering a template.
+func mod(i, j int) (int, error) {
+	if j == 0 {
+		return 0, errors.Errorf("division of %d by zero", i)
+	}
+	return i % j, nil
+}
+
+// quantityUnits are the suffixes of Kubernetes resource quantities, with the
+// empty unit for plain numbers such as whole CPUs.
+var quantityUnits = map[string]bool{
+	"": true, "n": true, "u": true, "m": true, "k": true, "M": true, "G": true, "T": true, "P": true, "E": true,
+	"Ki": true, "Mi": true, "Gi": true, "Ti": true, "Pi": true, "Ei": true,
+}
+
+// quantity returns the Kubernetes resource quantity of value in the given
+// unit, such as 512 "Mi" or 250 "m", in its canonical form, so 1024 "Mi" is
+// rendered as 1Gi. The value is an integer or a float, and must not be
+// negative.
+func quantity(value interface{}, unit string) (string, error) {
+	if !quantityUnits[unit] {
+		return "", errors.Errorf("unsupported quantity unit %q", unit)
+	}
+	var number string
+	switch v := reflect.ValueOf(value); v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		number = strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		number = strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		number = strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	default:
+		return "", errors.Errorf("unsupported quantity value type %T", value)
+	}
+	q, err := resource.ParseQuantity(number + unit)
+	if err != nil {
+		return "", errors.Wrapf(err, "invalid quantity %s%s", number, unit)
+	}
+	if q.Sign() < 0 {
+		return "", errors.Errorf("negative quantity %s%s", number, unit)
+	}
+	return q.String(), nil
+}
+
+// duration returns the number of seconds as a Go duration string in its
+// most readable form, leaving out the units that are zero, so 30 is
+// rendered as 30s, 300 as 5m and 5400 as 1h30m. The seconds must not be
+// negative.
+func duration(seconds int) (string, error) {
+	if seconds < 0 {
+		return "", errors.Errorf("negative duration %ds", seconds)
+	}
+	if seconds == 0 {
+		return "0s", nil
+	}
+	var b strings.Builder
+	for _, unit := range []struct {
+		suffix  string
+		seconds int
+	}{
+		{suffix: "h", seconds: 3600},
+		{suffix: "m", seconds: 60},
+		{suffix: "s", seconds: 1},
+	} {
+		if n := seconds / unit.seconds; n > 0 {
+			fmt.Fprintf(&b, "%d%s", n, unit.suffix)
+			seconds -= n * unit.seconds
+		}
+	}
+	return b.String(), nil
 }
 
 func indent(indention int, v string) string {
 	newline := "\n" + strings.Repeat(" ", indention)
 	return strings.Replace(v, "\n", newline, -1)
 }
+
+// nindent is like indent, but starts a new line and indents the first line
+// as well.
+func nindent(indention int, v string) string {
+	return "\n" + strings.Repeat(" ", indention) + indent(indention, v)
+}
+
+// shortHash returns a stable identifier derived from v that is short enough
+// to be used as a label value.
+func shortHash(v string) string {
+	sum := sha256.Sum256([]byte(v))
+	return hex.EncodeToString(sum[:])[:10]
+}
+
+// dns1123 normalizes v into an RFC 1123 label, e.g. for use in object names.
+// It is lowercased, characters other than alphanumerics and hyphens are
+// replaced with hyphens, and the result is truncated to 63 characters with
+// leading and trailing hyphens removed.
+func dns1123(v string) string {
+	label := strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '-'
+	}, strings.ToLower(v))
+	if len(label) > validation.DNS1123LabelMaxLength {
+		label = label[:validation.DNS1123LabelMaxLength]
+	}
+	return strings.Trim(label, "-")
+}
+
+func toYAML(v interface{}) (string, error) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(string(data), "\n"), nil
+}
@@ -0,0 +1,60 @@
+package manifests
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/tls"
+)
+
+// placeholderTLSAnnotation marks the objects generated with placeholder TLS
+// assets, which must never be applied to a cluster.
+const placeholderTLSAnnotation = "installer.openshift.io/placeholder-tls"
+
+// placeholderPEM returns a deterministic PEM-framed placeholder for the named
+// certificate or key. It is not valid PEM, so it cannot be mistaken for, or
+// used as, real TLS material.
+func placeholderPEM(blockType, name string) []byte {
+	return []byte(fmt.Sprintf("-----BEGIN %s-----\nPLACEHOLDER %s %s, NOT FOR USE IN A CLUSTER\n-----END %s-----\n", blockType, name, blockType, blockType))
+}
+
+func placeholderCertKey(c *tls.CertKey, name string) {
+	c.CertRaw = placeholderPEM("CERTIFICATE", name)
+	c.KeyRaw = placeholderPEM("RSA PRIVATE KEY", name)
+}
+
+func placeholderCertBundle(b *tls.CertBundle, name string) {
+	b.BundleRaw = placeholderPEM("CERTIFICATE", name)
+}
+
+// annotateGenerated returns a copy of the generated file with the provenance
+// annotations, and the placeholder TLS annotation if the placeholders are
+// used, added to the object it holds.
+func (m *Manifests) annotateGenerated(f *asset.File, source string) (*asset.File, error) {
+	annotated, err := annotateProvenance(f, source)
+	if err != nil || !m.PlaceholderTLS {
+		return annotated, err
+	}
+	return annotateFile(annotated, map[string]string{placeholderTLSAnnotation: "true"})
+}
+
+// placeholderTLSFile returns the name of the first of the files holding an
+// object with the placeholder TLS annotation, or an empty string if there is
+// none.
+func placeholderTLSFile(files []*asset.File) string {
+	for _, f := range files {
+		var obj struct {
+			Metadata metadata `json:"metadata"`
+		}
+		// Files that are not objects cannot carry the annotation.
+		if err := yaml.Unmarshal(f.Data, &obj); err != nil {
+			continue
+		}
+		if obj.Metadata.Annotations[placeholderTLSAnnotation] == "true" {
+			return f.Filename
+		}
+	}
+	return ""
+}
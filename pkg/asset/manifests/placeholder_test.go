@@ -0,0 +1,95 @@
+package manifests
+
+import (
+	"encoding/base64"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/mock"
+	"github.com/openshift/installer/pkg/asset/tls"
+)
+
+func TestManifestsPlaceholderTLS(t *testing.T) {
+	withTLS := &Manifests{}
+	generateTestAsset(t, testInstallConfig(), withTLS)
+
+	m := &Manifests{PlaceholderTLS: true}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	_, ok := parents[reflect.TypeOf(&tls.RootCA{})]
+	assert.False(t, ok, "the TLS assets are dependencies of the placeholder manifests")
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate placeholder manifests: %v", err)
+	}
+
+	filenames := func(m *Manifests) []string {
+		names := []string{}
+		for _, f := range m.FileList {
+			names = append(names, f.Filename)
+		}
+		return names
+	}
+	assert.Equal(t, filenames(withTLS), filenames(m))
+
+	files := m.FileMap()
+	secret := files[filepath.Join(manifestDir, "machine-config-server-tls-secret.yaml")]
+	var obj struct {
+		Metadata metadata          `json:"metadata"`
+		Data     map[string]string `json:"data"`
+	}
+	if err := yaml.Unmarshal(secret.Data, &obj); err != nil {
+		t.Fatalf("failed to unmarshal the machine-config-server TLS secret: %v", err)
+	}
+	assert.Equal(t, "true", obj.Metadata.Annotations[placeholderTLSAnnotation])
+	cert, err := base64.StdEncoding.DecodeString(obj.Data["tls.crt"])
+	if assert.NoError(t, err) {
+		assert.Equal(t, "-----BEGIN CERTIFICATE-----\nPLACEHOLDER machine-config-server CERTIFICATE, NOT FOR USE IN A CLUSTER\n-----END CERTIFICATE-----\n", string(cert))
+	}
+	assert.Contains(t, string(files[filepath.Join(manifestDir, "etcd-ca-bundle-configmap.yaml")].Data), "PLACEHOLDER etcd-ca-bundle CERTIFICATE")
+	assert.Contains(t, string(files[kubeSysConfigPath].Data), placeholderTLSAnnotation)
+
+	// The placeholders are deterministic.
+	again := &Manifests{PlaceholderTLS: true}
+	if err := again.Generate(parents); err != nil {
+		t.Fatalf("failed to regenerate placeholder manifests: %v", err)
+	}
+	assert.Equal(t, m.FileList, again.FileList)
+
+	for _, f := range withTLS.FileList {
+		assert.NotContains(t, string(f.Data), placeholderTLSAnnotation, "%s is marked as a placeholder", f.Filename)
+	}
+}
+
+func TestRegenerateTLSManifestsPlaceholderTLS(t *testing.T) {
+	m := &Manifests{PlaceholderTLS: true}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate placeholder manifests: %v", err)
+	}
+	generated := append([]*asset.File{}, m.FileList...)
+	if err := m.RegenerateTLSManifests(parents); err != nil {
+		t.Fatalf("failed to regenerate the placeholder TLS manifests: %v", err)
+	}
+	assert.Equal(t, generated, m.FileList)
+}
+
+func TestManifestsLoadPlaceholderTLS(t *testing.T) {
+	m := &Manifests{PlaceholderTLS: true}
+	generateTestAsset(t, testInstallConfig(), m)
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+	fileFetcher.EXPECT().FetchByPattern(filepath.Join(manifestDir, "*")).Return(m.FileList, nil)
+
+	found, err := (&Manifests{}).Load(fileFetcher)
+	assert.False(t, found)
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "was generated with placeholder TLS assets, so the manifests cannot be used to install a cluster")
+	}
+}
@@ -0,0 +1,35 @@
+package manifests
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// postProcessFiles returns copies of the files passed through PostProcess,
+// recording each copy under the source of its original when sources is set.
+// The files are copied first, since they may be shared with the assets that
+// generated them.
+func (m *Manifests) postProcessFiles(files []*asset.File, sources map[*asset.File]string) ([]*asset.File, error) {
+	if m.PostProcess == nil {
+		return files, nil
+	}
+	processed := make([]*asset.File, len(files))
+	for i, f := range files {
+		p := &asset.File{
+			Filename: f.Filename,
+			Data:     append([]byte(nil), f.Data...),
+		}
+		if err := m.PostProcess(p); err != nil {
+			return nil, errors.Wrapf(err, "failed to post-process %s", f.Filename)
+		}
+		if p.Filename != f.Filename {
+			return nil, errors.Errorf("post-processing %s renamed it to %s", f.Filename, p.Filename)
+		}
+		if sources != nil {
+			sources[p] = sources[f]
+		}
+		processed[i] = p
+	}
+	return processed, nil
+}
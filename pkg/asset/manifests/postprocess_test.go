@@ -0,0 +1,78 @@
+package manifests
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestManifestsPostProcess(t *testing.T) {
+	plain := &Manifests{}
+	generateTestAsset(t, testInstallConfig(), plain)
+
+	m := &Manifests{
+		PostProcess: func(f *asset.File) error {
+			f.Data = bytes.Replace(f.Data, []byte("k8s-app: etcd"), []byte("k8s-app: external-etcd"), -1)
+			return nil
+		},
+	}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+
+	service := m.FileMap()[filepath.Join(manifestDir, "etcd-service.yaml")]
+	if assert.NotNil(t, service, "missing etcd service") {
+		assert.Contains(t, string(service.Data), "k8s-app: external-etcd")
+		assert.NotContains(t, string(service.Data), "k8s-app: etcd\n")
+	}
+
+	// The checksums cover the processed files.
+	checksums, err := checksumsFile(m.FileList, manifestDir)
+	if assert.NoError(t, err, "unexpected error") {
+		assert.Equal(t, checksums.Data, m.FileMap()[checksumsFilename].Data, "unexpected checksums")
+	}
+
+	// The files of the assets the manifests were generated from are not
+	// modified.
+	serviceTemplate := plain.FileMap()[filepath.Join(manifestDir, "etcd-service.yaml")]
+	assert.Contains(t, string(serviceTemplate.Data), "k8s-app: etcd\n")
+	for _, p := range parents {
+		if w, ok := p.(asset.WritableAsset); ok {
+			for _, f := range w.Files() {
+				assert.NotContains(t, string(f.Data), "external-etcd", "%s of %s was modified", f.Filename, w.Name())
+			}
+		}
+	}
+}
+
+func TestManifestsPostProcessError(t *testing.T) {
+	m := &Manifests{
+		PostProcess: func(f *asset.File) error {
+			if filepath.Base(f.Filename) == "etcd-namespace.yaml" {
+				return errors.New("namespace rejected")
+			}
+			return nil
+		},
+	}
+	err := m.Generate(generateTestParents(t, testInstallConfig(), m))
+	assert.EqualError(t, err, "failed to post-process manifests/etcd-namespace.yaml: namespace rejected")
+}
+
+func TestManifestsPostProcessRename(t *testing.T) {
+	m := &Manifests{
+		PostProcess: func(f *asset.File) error {
+			if filepath.Base(f.Filename) == "etcd-namespace.yaml" {
+				f.Filename = filepath.Join(manifestDir, "renamed.yaml")
+			}
+			return nil
+		},
+	}
+	err := m.Generate(generateTestParents(t, testInstallConfig(), m))
+	assert.EqualError(t, err, "post-processing manifests/etcd-namespace.yaml renamed it to manifests/renamed.yaml")
+}
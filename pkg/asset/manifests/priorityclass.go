@@ -0,0 +1,95 @@
+package manifests
+
+import (
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// criticalPriorityClass is the PriorityClass of the pods the cluster cannot
+// run without, which keeps them from being evicted in favor of other
+// workloads. It is built in: the API server creates it and reserves the
+// system- prefix, so it is referenced rather than generated.
+const criticalPriorityClass = "system-cluster-critical"
+
+// podSpecPaths are the paths to the pod spec of each workload kind.
+var podSpecPaths = map[string][]string{
+	"Pod":         {"spec"},
+	"Deployment":  {"spec", "template", "spec"},
+	"DaemonSet":   {"spec", "template", "spec"},
+	"StatefulSet": {"spec", "template", "spec"},
+	"ReplicaSet":  {"spec", "template", "spec"},
+	"Job":         {"spec", "template", "spec"},
+	"CronJob":     {"spec", "jobTemplate", "spec", "template", "spec"},
+}
+
+// criticalWorkload identifies a workload by its kind, namespace and name.
+type criticalWorkload struct {
+	kind      string
+	namespace string
+	name      string
+}
+
+// criticalWorkloads are the workloads the installer generates that the
+// control plane depends on, and only they are given the critical
+// PriorityClass. The etcd backup CronJob is the only one: the etcd members and
+// the machine-config-server are not rendered from the installer's manifests,
+// so their priority is set by the operators that run them.
+var criticalWorkloads = map[criticalWorkload]bool{
+	{kind: "CronJob", namespace: "openshift-etcd", name: "etcd-backup"}: true,
+}
+
+// setCriticalPriorityClass returns a copy of the file with the pod spec of
+// the workload it holds set to the critical PriorityClass, if the workload is
+// one of the criticalWorkloads. Pod specs that name a PriorityClass already,
+// and files that do not hold a single critical workload, are returned
+// unchanged.
+func setCriticalPriorityClass(f *asset.File) (*asset.File, error) {
+	docs := 0
+	for _, doc := range documentSeparator.Split(string(f.Data), -1) {
+		if strings.TrimSpace(doc) != "" {
+			docs++
+		}
+	}
+	if docs != 1 {
+		return f, nil
+	}
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal(f.Data, &obj); err != nil {
+		return f, nil
+	}
+	kind, _ := obj["kind"].(string)
+	meta, _ := obj["metadata"].(map[string]interface{})
+	namespace, _ := meta["namespace"].(string)
+	name, _ := meta["name"].(string)
+	if !criticalWorkloads[criticalWorkload{kind: kind, namespace: namespace, name: name}] {
+		return f, nil
+	}
+	path, ok := podSpecPaths[kind]
+	if !ok {
+		return f, nil
+	}
+
+	spec := obj
+	for _, field := range path {
+		if spec, ok = spec[field].(map[string]interface{}); !ok {
+			return f, nil
+		}
+	}
+	if className, _ := spec["priorityClassName"].(string); className != "" {
+		return f, nil
+	}
+	spec["priorityClassName"] = criticalPriorityClass
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %s", f.Filename)
+	}
+	return &asset.File{
+		Filename: f.Filename,
+		Data:     data,
+	}, nil
+}
@@ -0,0 +1,142 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestSetCriticalPriorityClass(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name:     "etcd backup cronjob",
+			data:     "apiVersion: batch/v1beta1\nkind: CronJob\nmetadata:\n  name: etcd-backup\n  namespace: openshift-etcd\nspec:\n  jobTemplate:\n    spec:\n      template:\n        spec:\n          restartPolicy: Never\n",
+			expected: "apiVersion: batch/v1beta1\nkind: CronJob\nmetadata:\n  name: etcd-backup\n  namespace: openshift-etcd\nspec:\n  jobTemplate:\n    spec:\n      template:\n        spec:\n          priorityClassName: system-cluster-critical\n          restartPolicy: Never\n",
+		},
+		{
+			name:     "workload that is not allowlisted",
+			data:     "apiVersion: apps/v1\nkind: DaemonSet\nmetadata:\n  name: machine-config-server\n  namespace: openshift-machine-config-operator\nspec:\n  template:\n    spec:\n      hostNetwork: true\n",
+			expected: "apiVersion: apps/v1\nkind: DaemonSet\nmetadata:\n  name: machine-config-server\n  namespace: openshift-machine-config-operator\nspec:\n  template:\n    spec:\n      hostNetwork: true\n",
+		},
+		{
+			name:     "same name in another namespace",
+			data:     "apiVersion: batch/v1beta1\nkind: CronJob\nmetadata:\n  name: etcd-backup\n  namespace: default\nspec:\n  jobTemplate:\n    spec:\n      template:\n        spec:\n          restartPolicy: Never\n",
+			expected: "apiVersion: batch/v1beta1\nkind: CronJob\nmetadata:\n  name: etcd-backup\n  namespace: default\nspec:\n  jobTemplate:\n    spec:\n      template:\n        spec:\n          restartPolicy: Never\n",
+		},
+		{
+			name:     "existing priority class",
+			data:     "apiVersion: batch/v1beta1\nkind: CronJob\nmetadata:\n  name: etcd-backup\n  namespace: openshift-etcd\nspec:\n  jobTemplate:\n    spec:\n      template:\n        spec:\n          priorityClassName: system-node-critical\n",
+			expected: "apiVersion: batch/v1beta1\nkind: CronJob\nmetadata:\n  name: etcd-backup\n  namespace: openshift-etcd\nspec:\n  jobTemplate:\n    spec:\n      template:\n        spec:\n          priorityClassName: system-node-critical\n",
+		},
+		{
+			name:     "not a workload",
+			data:     "apiVersion: v1\nkind: Service\nspec:\n  clusterIP: None\n",
+			expected: "apiVersion: v1\nkind: Service\nspec:\n  clusterIP: None\n",
+		},
+		{
+			name:     "no pod template",
+			data:     "apiVersion: batch/v1beta1\nkind: CronJob\nmetadata:\n  name: etcd-backup\n  namespace: openshift-etcd\nspec: {}\n",
+			expected: "apiVersion: batch/v1beta1\nkind: CronJob\nmetadata:\n  name: etcd-backup\n  namespace: openshift-etcd\nspec: {}\n",
+		},
+		{
+			name:     "several documents",
+			data:     "apiVersion: batch/v1beta1\nkind: CronJob\nmetadata:\n  name: etcd-backup\n  namespace: openshift-etcd\nspec: {}\n---\napiVersion: v1\nkind: Pod\nspec: {}\n",
+			expected: "apiVersion: batch/v1beta1\nkind: CronJob\nmetadata:\n  name: etcd-backup\n  namespace: openshift-etcd\nspec: {}\n---\napiVersion: v1\nkind: Pod\nspec: {}\n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &asset.File{Filename: "manifests/test.yaml", Data: []byte(tc.data)}
+			patched, err := setCriticalPriorityClass(f)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tc.expected, string(patched.Data))
+				assert.Equal(t, tc.data, string(f.Data), "the file was modified")
+			}
+		})
+	}
+}
+
+// testWorkloadTemplate is a bootkube template asset of a workload.
+type testWorkloadTemplate struct {
+	FileList []*asset.File
+}
+
+func (t *testWorkloadTemplate) Dependencies() []asset.Asset { return []asset.Asset{} }
+
+func (t *testWorkloadTemplate) Name() string { return "TestWorkloadTemplate" }
+
+func (t *testWorkloadTemplate) Generate(asset.Parents) error {
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join("templates", "test-machine-config-server.yaml.template"),
+			Data:     []byte("apiVersion: apps/v1\nkind: DaemonSet\nmetadata:\n  name: machine-config-server\n  namespace: openshift-machine-config-operator\nspec:\n  template:\n    spec:\n      containers:\n      - name: machine-config-server\n"),
+		},
+	}
+	return nil
+}
+
+func (t *testWorkloadTemplate) Files() []*asset.File { return t.FileList }
+
+func (t *testWorkloadTemplate) Load(asset.FileFetcher) (bool, error) { return false, nil }
+
+func TestManifestsCriticalPriorityClass(t *testing.T) {
+	defer func(registered []func() asset.WritableAsset) {
+		registeredBootkubeTemplates = registered
+	}(registeredBootkubeTemplates)
+	RegisterBootkubeTemplate(func() asset.WritableAsset { return &testWorkloadTemplate{} })
+
+	ic := testInstallConfig()
+	ic.EtcdBackup = &types.EtcdBackup{Schedule: "0 0 * * *", Image: "quay.io/openshift/etcd-backup:latest"}
+	m := &Manifests{}
+	parents := generateTestParents(t, ic, m)
+	userDaemonSet := "apiVersion: apps/v1\nkind: DaemonSet\nmetadata:\n  name: user\n  namespace: openshift-config\nspec:\n  template:\n    spec:\n      containers:\n      - name: user\n"
+	parents.Add(&UserManifests{
+		FileList: []*asset.File{{Filename: "openshift/99-user-daemonset.yaml", Data: []byte(userDaemonSet)}},
+	})
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+
+	files := m.FileMap()
+	var backup struct {
+		Spec struct {
+			JobTemplate struct {
+				Spec struct {
+					Template struct {
+						Spec struct {
+							PriorityClassName string `json:"priorityClassName"`
+						} `json:"spec"`
+					} `json:"template"`
+				} `json:"spec"`
+			} `json:"jobTemplate"`
+		} `json:"spec"`
+	}
+	backupFile := files[filepath.Join(manifestDir, "etcd-backup-cronjob.yaml")]
+	if !assert.NotNil(t, backupFile, "missing etcd backup cronjob") {
+		return
+	}
+	if err := yaml.Unmarshal(backupFile.Data, &backup); err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "system-cluster-critical", backup.Spec.JobTemplate.Spec.Template.Spec.PriorityClassName)
+
+	// Only the allowlisted workloads are patched.
+	assert.NotContains(t, string(files[filepath.Join(manifestDir, "test-machine-config-server.yaml")].Data), "priorityClassName")
+
+	// User manifests are not the installer's to patch.
+	assert.Equal(t, userDaemonSet, string(files[filepath.Join(manifestDir, "99-user-daemonset.yaml")].Data))
+
+	// The PriorityClass is built in, so it is not generated.
+	for _, f := range m.FileList {
+		assert.NotContains(t, string(f.Data), "kind: PriorityClass", "%s holds a PriorityClass", f.Filename)
+	}
+}
@@ -0,0 +1,104 @@
+package manifests
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/version"
+)
+
+const (
+	// generatedByAnnotation records the asset that generated an object.
+	generatedByAnnotation = "installer.openshift.io/generated-by"
+	// installerVersionAnnotation records the version of the installer that
+	// generated an object.
+	installerVersionAnnotation = "installer.openshift.io/version"
+)
+
+var installerProvenanceFilename = filepath.Join(manifestDir, "installer-provenance.yaml")
+
+// installerProvenanceFile returns the openshift-config/installer-provenance
+// configmap, which records the names of the assets the manifests were
+// generated from and the build of the installer. It holds no asset content,
+// so it is identical for every cluster generated by the same build.
+func installerProvenanceFile(dependencies []asset.Asset) (*asset.File, error) {
+	names := make([]string, len(dependencies))
+	for i, a := range dependencies {
+		names[i] = a.Name()
+	}
+	cm := configMap("openshift-config", "installer-provenance", genericData{
+		"commit":       version.Commit,
+		"dependencies": strings.Join(names, "\n"),
+		"version":      version.Raw,
+	})
+	data, err := yaml.Marshal(cm)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create openshift-config/installer-provenance configmap")
+	}
+	return &asset.File{
+		Filename: installerProvenanceFilename,
+		Data:     data,
+	}, nil
+}
+
+// annotateProvenance returns a copy of the file with the provenance
+// annotations added to the object it holds. Files that do not hold a single
+// Kubernetes object, such as YAML streams of several documents, are returned
+// unchanged.
+func annotateProvenance(f *asset.File, source string) (*asset.File, error) {
+	return annotateFile(f, map[string]string{
+		generatedByAnnotation:      source,
+		installerVersionAnnotation: version.Raw,
+	})
+}
+
+// annotateFile returns a copy of the file with the annotations added to the
+// object it holds, or the file itself if it does not hold a single object.
+func annotateFile(f *asset.File, extra map[string]string) (*asset.File, error) {
+	docs := 0
+	for _, doc := range documentSeparator.Split(string(f.Data), -1) {
+		if strings.TrimSpace(doc) != "" {
+			docs++
+		}
+	}
+	if docs != 1 {
+		return f, nil
+	}
+	var obj map[string]interface{}
+	if err := yaml.Unmarshal(f.Data, &obj); err != nil || obj["apiVersion"] == nil || obj["kind"] == nil {
+		return f, nil
+	}
+
+	metadata, ok := obj["metadata"].(map[string]interface{})
+	if !ok {
+		if obj["metadata"] != nil {
+			return f, nil
+		}
+		metadata = map[string]interface{}{}
+		obj["metadata"] = metadata
+	}
+	annotations, ok := metadata["annotations"].(map[string]interface{})
+	if !ok {
+		if metadata["annotations"] != nil {
+			return f, nil
+		}
+		annotations = map[string]interface{}{}
+		metadata["annotations"] = annotations
+	}
+	for k, v := range extra {
+		annotations[k] = v
+	}
+
+	data, err := yaml.Marshal(obj)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to marshal %s", f.Filename)
+	}
+	return &asset.File{
+		Filename: f.Filename,
+		Data:     data,
+	}, nil
+}
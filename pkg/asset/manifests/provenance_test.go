@@ -0,0 +1,153 @@
+package manifests
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/version"
+)
+
+func TestAnnotateProvenance(t *testing.T) {
+	cases := []struct {
+		name     string
+		data     string
+		expected string
+	}{
+		{
+			name: "object without metadata",
+			data: "apiVersion: v1\nkind: Namespace\n",
+			expected: `apiVersion: v1
+kind: Namespace
+metadata:
+  annotations:
+    installer.openshift.io/generated-by: Test Asset
+    installer.openshift.io/version: ` + version.Raw + `
+`,
+		},
+		{
+			name: "object with annotations",
+			data: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n  annotations:\n    existing: value\ndata:\n  port: \"6443\"\n",
+			expected: `apiVersion: v1
+data:
+  port: "6443"
+kind: ConfigMap
+metadata:
+  annotations:
+    existing: value
+    installer.openshift.io/generated-by: Test Asset
+    installer.openshift.io/version: ` + version.Raw + `
+  name: test
+`,
+		},
+		{
+			name: "leading document separator",
+			data: "---\napiVersion: v1\nkind: Namespace\n",
+			expected: `apiVersion: v1
+kind: Namespace
+metadata:
+  annotations:
+    installer.openshift.io/generated-by: Test Asset
+    installer.openshift.io/version: ` + version.Raw + `
+`,
+		},
+		{
+			name:     "multiple documents",
+			data:     "apiVersion: v1\nkind: Namespace\n---\napiVersion: v1\nkind: Service\n",
+			expected: "apiVersion: v1\nkind: Namespace\n---\napiVersion: v1\nkind: Service\n",
+		},
+		{
+			name:     "not an object",
+			data:     "manifests/cluster-config.yaml: 0123abcd\n",
+			expected: "manifests/cluster-config.yaml: 0123abcd\n",
+		},
+		{
+			name:     "invalid",
+			data:     "kind: [Namespace\n",
+			expected: "kind: [Namespace\n",
+		},
+		{
+			name:     "empty",
+			expected: "",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f := &asset.File{Filename: "manifests/test.yaml", Data: []byte(tc.data)}
+			annotated, err := annotateProvenance(f, "Test Asset")
+			if assert.NoError(t, err, "unexpected error") {
+				assert.Equal(t, tc.expected, string(annotated.Data))
+				assert.Equal(t, tc.data, string(f.Data), "original file was modified")
+			}
+		})
+	}
+}
+
+func TestManifestsProvenanceAnnotations(t *testing.T) {
+	m := &Manifests{}
+	generateTestAsset(t, testInstallConfig(), m)
+
+	cases := []struct {
+		filename string
+		source   string
+	}{
+		{filename: "manifests/cluster-config.yaml", source: "Install Config"},
+		{filename: "manifests/etcd-signer-secret.yaml", source: "EtcdSignerSecret"},
+		{filename: "manifests/cluster-network-02-config.yml", source: "Network Config"},
+	}
+	files := map[string]*asset.File{}
+	for _, f := range m.FileList {
+		files[f.Filename] = f
+	}
+	for _, tc := range cases {
+		t.Run(tc.filename, func(t *testing.T) {
+			f, ok := files[tc.filename]
+			if !assert.True(t, ok, "%s was not generated", tc.filename) {
+				return
+			}
+			var obj struct {
+				Metadata struct {
+					Annotations map[string]string `json:"annotations"`
+				} `json:"metadata"`
+			}
+			if assert.NoError(t, yaml.Unmarshal(f.Data, &obj), "failed to parse %s", tc.filename) {
+				assert.Equal(t, tc.source, obj.Metadata.Annotations[generatedByAnnotation])
+				assert.Equal(t, version.Raw, obj.Metadata.Annotations[installerVersionAnnotation])
+			}
+		})
+	}
+}
+
+func TestManifestsInstallerProvenance(t *testing.T) {
+	generateProvenance := func() *configurationObject {
+		m := &Manifests{}
+		generateTestAsset(t, testInstallConfig(), m)
+		f := m.FileMap()[installerProvenanceFilename]
+		if f == nil {
+			t.Fatalf("%s was not generated", installerProvenanceFilename)
+		}
+		cm := &configurationObject{}
+		if err := yaml.Unmarshal(f.Data, cm); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", installerProvenanceFilename, err)
+		}
+		return cm
+	}
+
+	cm := generateProvenance()
+	assert.Equal(t, "openshift-config", cm.Metadata.Namespace)
+	assert.Equal(t, "installer-provenance", cm.Metadata.Name)
+	assert.Equal(t, version.Raw, cm.Data["version"])
+	assert.Equal(t, version.Commit, cm.Data["commit"])
+
+	dependencies := (&Manifests{}).Dependencies()
+	names := make([]string, len(dependencies))
+	for i, a := range dependencies {
+		names[i] = a.Name()
+	}
+	assert.Equal(t, names, strings.Split(cm.Data["dependencies"], "\n"), "unexpected dependencies")
+
+	assert.Equal(t, cm.Data, generateProvenance().Data, "provenance differs between identical generations")
+}
@@ -150,9 +150,8 @@ func createNoProxy(installConfig *installconfig.InstallConfig, network *Networki
 		set.Insert("metadata", "metadata.google.internal", "metadata.google.internal.")
 	}
 
-	for i := int64(0); i < *installConfig.Config.ControlPlane.Replicas; i++ {
-		etcdHost := fmt.Sprintf("etcd-%d.%s", i, installConfig.Config.ClusterDomain())
-		set.Insert(etcdHost)
+	for _, hostname := range EtcdEndpointHostnames(installConfig.Config) {
+		set.Insert(fmt.Sprintf("%s.%s", hostname, installConfig.Config.EtcdDiscoveryDomain()))
 	}
 
 	for _, clusterNetwork := range network.Config.Spec.ClusterNetwork {
@@ -0,0 +1,74 @@
+package manifests
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// MirrorPullSecret returns the pull secret with auth entries added for the
+// registry mirrors of the image content sources that set
+// CopySourceCredentials. A mirror host of those without an entry of its own
+// is given the credentials of its source's registry host, which are thereby
+// sent to the mirror. Existing entries are never overwritten, and the pull
+// secret is returned unchanged when no entry needs to be added.
+func MirrorPullSecret(pullSecret string, sources []types.ImageContentSource) (string, error) {
+	copied := false
+	for _, source := range sources {
+		copied = copied || source.CopySourceCredentials
+	}
+	if !copied {
+		return pullSecret, nil
+	}
+
+	var secret map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(pullSecret), &secret); err != nil {
+		return "", errors.Wrap(err, "failed to parse pull secret")
+	}
+	var auths map[string]json.RawMessage
+	if err := json.Unmarshal(secret["auths"], &auths); err != nil {
+		return "", errors.Wrap(err, "failed to parse pull secret auths")
+	}
+
+	added := false
+	for _, source := range sources {
+		if !source.CopySourceCredentials {
+			continue
+		}
+		auth, ok := auths[registryHost(source.Source)]
+		if !ok {
+			continue
+		}
+		for _, mirror := range source.Mirrors {
+			host := registryHost(mirror)
+			if _, ok := auths[host]; ok {
+				continue
+			}
+			auths[host] = auth
+			added = true
+		}
+	}
+	if !added {
+		return pullSecret, nil
+	}
+
+	data, err := json.Marshal(auths)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal pull secret auths")
+	}
+	secret["auths"] = data
+	data, err = json.Marshal(secret)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal pull secret")
+	}
+	return string(data), nil
+}
+
+// registryHost returns the registry host of a repository, e.g. quay.io for
+// quay.io/openshift-release-dev/ocp-release.
+func registryHost(repository string) string {
+	return strings.SplitN(repository, "/", 2)[0]
+}
@@ -0,0 +1,113 @@
+package manifests
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/mock"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestMirrorPullSecret(t *testing.T) {
+	sources := []types.ImageContentSource{{
+		Source:                "quay.io/openshift-release-dev/ocp-release",
+		Mirrors:               []string{"mirror.example.com:5000/ocp/release"},
+		CopySourceCredentials: true,
+	}}
+	cases := []struct {
+		name       string
+		pullSecret string
+		sources    []types.ImageContentSource
+		expected   string
+	}{
+		{
+			name:       "no image content sources",
+			pullSecret: `{"auths":{"quay.io":{"auth":"c291cmNl"}}}`,
+			expected:   `{"auths":{"quay.io":{"auth":"c291cmNl"}}}`,
+		},
+		{
+			name:       "source credentials not copied by default",
+			pullSecret: `{"auths":{"quay.io":{"auth":"c291cmNl"}}}`,
+			sources: []types.ImageContentSource{{
+				Source:  "quay.io/openshift-release-dev/ocp-release",
+				Mirrors: []string{"mirror.example.com:5000/ocp/release"},
+			}},
+			expected: `{"auths":{"quay.io":{"auth":"c291cmNl"}}}`,
+		},
+		{
+			name:       "mirror entry added",
+			pullSecret: `{"auths":{"quay.io":{"auth":"c291cmNl","email":"user@example.com"}}}`,
+			sources:    sources,
+			expected:   `{"auths":{"mirror.example.com:5000":{"auth":"c291cmNl","email":"user@example.com"},"quay.io":{"auth":"c291cmNl","email":"user@example.com"}}}`,
+		},
+		{
+			name:       "existing mirror entry unchanged",
+			pullSecret: `{"auths":{"mirror.example.com:5000":{"auth":"bWlycm9y"},"quay.io":{"auth":"c291cmNl"}}}`,
+			sources:    sources,
+			expected:   `{"auths":{"mirror.example.com:5000":{"auth":"bWlycm9y"},"quay.io":{"auth":"c291cmNl"}}}`,
+		},
+		{
+			name:       "source without credentials",
+			pullSecret: `{"auths":{"registry.example.com":{"auth":"b3RoZXI="}}}`,
+			sources:    sources,
+			expected:   `{"auths":{"registry.example.com":{"auth":"b3RoZXI="}}}`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pullSecret, err := MirrorPullSecret(tc.pullSecret, tc.sources)
+			if assert.NoError(t, err, "unexpected error") {
+				assert.Equal(t, tc.expected, pullSecret, "unexpected pull secret")
+			}
+		})
+	}
+}
+
+func TestManifestsPullSecretFile(t *testing.T) {
+	inline := testInstallConfig()
+
+	// The file is read when the install-config is loaded.
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	reference := *inline
+	reference.APIVersion = types.InstallConfigVersion
+	reference.PullSecret = types.PullSecretFilePrefix + "pull-secret.json"
+	data, err := yaml.Marshal(&reference)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+	fileFetcher.EXPECT().FetchByName("install-config.yaml").Return(&asset.File{Filename: "install-config.yaml", Data: data}, nil)
+	fileFetcher.EXPECT().FetchByName("pull-secret.json").Return(&asset.File{Filename: "pull-secret.json", Data: []byte(inline.PullSecret + "\n")}, nil)
+	loaded := &installconfig.InstallConfig{}
+	if _, err := loaded.Load(fileFetcher); err != nil {
+		t.Fatalf("failed to load the install-config: %v", err)
+	}
+
+	var pullSecrets [][]byte
+	for _, ic := range []*types.InstallConfig{inline, loaded.Config} {
+		m := &Manifests{}
+		generateTestAsset(t, ic, m)
+
+		var secret corev1.Secret
+		f := m.FileMap()[filepath.Join(manifestDir, "openshift-config-secret-pull-secret.yaml")]
+		if err := yaml.Unmarshal(f.Data, &secret); err != nil {
+			t.Fatalf("failed to unmarshal the pull secret: %v", err)
+		}
+		pullSecrets = append(pullSecrets, secret.Data[".dockerconfigjson"])
+
+		clusterConfig := string(m.FileMap()[filepath.Join(manifestDir, "cluster-config.yaml")].Data)
+		assert.NotContains(t, clusterConfig, "authorization value", "the pull secret was not redacted")
+		assert.True(t, strings.Contains(clusterConfig, "pullSecret: \"\""), "unexpected pull secret in the install-config")
+	}
+	assert.Equal(t, []byte(inline.PullSecret), pullSecrets[0])
+	assert.Equal(t, pullSecrets[0], pullSecrets[1], "the file-referenced pull secret differs from the inline one")
+}
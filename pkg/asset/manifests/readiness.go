@@ -0,0 +1,82 @@
+package manifests
+
+import (
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+var bootstrapReadinessFilename = filepath.Join(manifestDir, "bootstrap-readiness.yaml")
+
+// bootstrapCriticalManifests are the bootkube manifests whose objects must
+// exist in the cluster before the bootstrap node can be removed.
+var bootstrapCriticalManifests = map[string]bool{
+	"cvo-overrides.yaml":                    true,
+	"etcd-service.yaml":                     true,
+	"machine-config-server-tls-secret.yaml": true,
+}
+
+// readinessObject identifies an object the bootstrap-complete check waits
+// for.
+type readinessObject struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Name       string `json:"name"`
+}
+
+// bootstrapReadinessFile returns the kube-system/bootstrap-readiness
+// configmap, which lists the objects of the critical manifests among the
+// rendered bootkube manifests. Critical manifests that are not generated,
+// such as the etcd service of an external etcd cluster or skipped
+// manifests, are not listed.
+func bootstrapReadinessFile(bootKubeFiles []*asset.File) (*asset.File, error) {
+	objects, err := bootstrapReadinessObjects(bootKubeFiles)
+	if err != nil {
+		return nil, err
+	}
+	objectsData, err := yaml.Marshal(objects)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the bootstrap readiness objects")
+	}
+	cm := configMap("kube-system", "bootstrap-readiness", genericData{
+		"objects": string(objectsData),
+	})
+	data, err := yaml.Marshal(cm)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create kube-system/bootstrap-readiness configmap")
+	}
+	return &asset.File{
+		Filename: bootstrapReadinessFilename,
+		Data:     data,
+	}, nil
+}
+
+// bootstrapReadinessObjects returns the objects of the critical manifests
+// among the files, in the order of the files.
+func bootstrapReadinessObjects(files []*asset.File) ([]readinessObject, error) {
+	objects := []readinessObject{}
+	for _, f := range files {
+		if !bootstrapCriticalManifests[filepath.Base(f.Filename)] {
+			continue
+		}
+		var obj struct {
+			APIVersion string   `json:"apiVersion"`
+			Kind       string   `json:"kind"`
+			Metadata   metadata `json:"metadata"`
+		}
+		if err := yaml.Unmarshal(f.Data, &obj); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse %s", f.Filename)
+		}
+		objects = append(objects, readinessObject{
+			APIVersion: obj.APIVersion,
+			Kind:       obj.Kind,
+			Namespace:  obj.Metadata.Namespace,
+			Name:       obj.Metadata.Name,
+		})
+	}
+	return objects, nil
+}
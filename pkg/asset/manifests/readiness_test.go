@@ -0,0 +1,78 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func readinessObjects(t *testing.T, m *Manifests) []readinessObject {
+	f := m.FileMap()[bootstrapReadinessFilename]
+	if f == nil {
+		t.Fatalf("%s was not generated", bootstrapReadinessFilename)
+	}
+	cm := &configurationObject{}
+	if err := yaml.Unmarshal(f.Data, cm); err != nil {
+		t.Fatalf("failed to unmarshal %s: %v", bootstrapReadinessFilename, err)
+	}
+	assert.Equal(t, "kube-system", cm.Metadata.Namespace)
+	assert.Equal(t, "bootstrap-readiness", cm.Metadata.Name)
+	objects := []readinessObject{}
+	if err := yaml.Unmarshal([]byte(cm.Data["objects"]), &objects); err != nil {
+		t.Fatalf("failed to unmarshal the readiness objects: %v", err)
+	}
+	return objects
+}
+
+func TestManifestsBootstrapReadiness(t *testing.T) {
+	m := &Manifests{}
+	generateTestAsset(t, testInstallConfig(), m)
+
+	critical := []*asset.File{}
+	for _, f := range m.FileList {
+		if bootstrapCriticalManifests[filepath.Base(f.Filename)] {
+			critical = append(critical, f)
+		}
+	}
+	assert.Len(t, critical, len(bootstrapCriticalManifests), "missing critical manifests")
+	expected, err := bootstrapReadinessObjects(critical)
+	if !assert.NoError(t, err) {
+		return
+	}
+	assert.Equal(t, expected, readinessObjects(t, m))
+	assert.Contains(t, expected, readinessObject{
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Namespace:  "openshift-machine-config-operator",
+		Name:       "machine-config-server-tls",
+	})
+}
+
+func TestManifestsBootstrapReadinessExternalEtcd(t *testing.T) {
+	_, externalBundle, _ := generateEtcdCerts(t)
+	ic := testInstallConfig()
+	ic.ExternalEtcd = &types.ExternalEtcd{
+		Hostnames: []string{"etcd-a", "etcd-b", "etcd-c"},
+		CABundle:  string(externalBundle.Cert()),
+	}
+	m := &Manifests{}
+	generateTestAsset(t, ic, m)
+
+	for _, obj := range readinessObjects(t, m) {
+		assert.False(t, obj.Kind == "Service" && obj.Name == "etcd", "unexpected etcd service for an external etcd")
+	}
+}
+
+func TestManifestsBootstrapReadinessSkipped(t *testing.T) {
+	m := &Manifests{SkippedManifests: []string{"etcd-service.yaml"}}
+	generateTestAsset(t, testInstallConfig(), m)
+
+	for _, obj := range readinessObjects(t, m) {
+		assert.NotEqual(t, "Service", obj.Kind, "unexpected object of a skipped manifest")
+	}
+}
@@ -0,0 +1,124 @@
+package manifests
+
+import (
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// selectorSegment matches one segment of a field selector: a field name
+// optionally followed by a list index, as in "hosts[0]".
+var selectorSegment = regexp.MustCompile(`^([^\[\]]+)(?:\[([0-9]+)\])?$`)
+
+// redactFields blanks the string fields of the YAML document named by the
+// selectors. A selector is a dot-separated path of JSON field names, where a
+// list element is selected by appending its index, for example
+// "platform.baremetal.hosts[0].bmc.address". It is an error for a selector to
+// name a field that is not present or that is not a string.
+func redactFields(data []byte, selectors []string) ([]byte, error) {
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	for _, selector := range selectors {
+		if err := redactField(doc, selector); err != nil {
+			return nil, err
+		}
+	}
+	return yaml.Marshal(doc)
+}
+
+func redactField(doc interface{}, selector string) error {
+	segments := strings.Split(selector, ".")
+	node := doc
+	for i, segment := range segments {
+		match := selectorSegment.FindStringSubmatch(segment)
+		if match == nil {
+			return errors.Errorf("invalid selector %q", selector)
+		}
+		fields, ok := node.(map[string]interface{})
+		if !ok {
+			return errors.Errorf("selector %q: %s is not an object", selector, strings.Join(segments[:i], "."))
+		}
+		value, ok := fields[match[1]]
+		if !ok {
+			return errors.Errorf("selector %q: unknown field %q", selector, match[1])
+		}
+
+		last := i == len(segments)-1
+		if match[2] == "" {
+			if last {
+				if _, ok := value.(string); !ok {
+					return errors.Errorf("selector %q does not select a string", selector)
+				}
+				fields[match[1]] = ""
+				return nil
+			}
+			node = value
+			continue
+		}
+
+		list, ok := value.([]interface{})
+		if !ok {
+			return errors.Errorf("selector %q: %s is not a list", selector, match[1])
+		}
+		index, err := strconv.Atoi(match[2])
+		if err != nil || index >= len(list) {
+			return errors.Errorf("selector %q: index %s is out of range", selector, match[2])
+		}
+		if last {
+			if _, ok := list[index].(string); !ok {
+				return errors.Errorf("selector %q does not select a string", selector)
+			}
+			list[index] = ""
+			return nil
+		}
+		node = list[index]
+	}
+	return nil
+}
+
+// verifyRedactedInstallConfig returns an error naming the top-level fields
+// of the redacted install-config that differ from the live install-config
+// once it is redacted with the same extra fields. It guards against the
+// configmap being generated from another install-config than the rest of the
+// manifests.
+func verifyRedactedInstallConfig(live *types.InstallConfig, redacted []byte, extraFields []string) error {
+	expected, err := redactedInstallConfig(*live, extraFields)
+	if err != nil {
+		return errors.Wrap(err, "failed to redact the install-config")
+	}
+	var expectedFields, redactedFields map[string]interface{}
+	if err := yaml.Unmarshal(expected, &expectedFields); err != nil {
+		return err
+	}
+	if err := yaml.Unmarshal(redacted, &redactedFields); err != nil {
+		return errors.Wrap(err, "failed to unmarshal the redacted install-config")
+	}
+
+	fields := map[string]bool{}
+	for field := range expectedFields {
+		fields[field] = true
+	}
+	for field := range redactedFields {
+		fields[field] = true
+	}
+	diverged := []string{}
+	for field := range fields {
+		if !reflect.DeepEqual(expectedFields[field], redactedFields[field]) {
+			diverged = append(diverged, field)
+		}
+	}
+	if len(diverged) > 0 {
+		sort.Strings(diverged)
+		return errors.Errorf("the redacted install-config differs from the install-config in %s", strings.Join(diverged, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package manifests
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// templateCache holds rendered templates, keyed by a digest of the template,
+// its delimiters and the template data. It is disabled until
+// SetTemplateCacheSize is called.
+var templateCache = &renderCache{}
+
+// SetTemplateCacheSize sets the number of rendered templates that are kept
+// for reuse by later generations with identical inputs, which helps when
+// many clusters are generated by one long-running process. A size of zero,
+// the default, disables the cache.
+func SetTemplateCacheSize(size int) {
+	templateCache.resize(size)
+}
+
+// renderCache is a size-bounded, least-recently-used cache of rendered
+// templates. It is safe for concurrent use.
+type renderCache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[[sha256.Size]byte]*list.Element
+}
+
+type renderCacheEntry struct {
+	key  [sha256.Size]byte
+	data []byte
+}
+
+// templateDataDigest returns a digest of the template data, or false if the
+// data cannot be serialized.
+func templateDataDigest(templateData interface{}) ([sha256.Size]byte, bool) {
+	data, err := json.Marshal(templateData)
+	if err != nil {
+		return [sha256.Size]byte{}, false
+	}
+	return sha256.Sum256([]byte(fmt.Sprintf("%T\n%s", templateData, data))), true
+}
+
+// renderCacheKey returns the cache key for rendering the template with the
+// given delimiters and the template data with the given digest.
+func renderCacheKey(tmpl []byte, left, right string, dataDigest [sha256.Size]byte) [sha256.Size]byte {
+	h := sha256.New()
+	// The lengths keep the fields from running into each other.
+	fmt.Fprintf(h, "%d:%s%d:%s%d:%s", len(left), left, len(right), right, len(tmpl), tmpl)
+	h.Write(dataDigest[:])
+	var key [sha256.Size]byte
+	copy(key[:], h.Sum(nil))
+	return key
+}
+
+func (c *renderCache) resize(size int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.size = size
+	if size <= 0 {
+		c.order, c.entries = nil, nil
+		return
+	}
+	if c.order == nil {
+		c.order = list.New()
+		c.entries = map[[sha256.Size]byte]*list.Element{}
+	}
+	c.evict()
+}
+
+// enabled returns whether renderings are cached.
+func (c *renderCache) enabled() bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.size > 0
+}
+
+// get returns a copy of the cached rendering for the key.
+func (c *renderCache) get(key [sha256.Size]byte) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size <= 0 {
+		return nil, false
+	}
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return append([]byte(nil), e.Value.(*renderCacheEntry).data...), true
+}
+
+// add stores a copy of the rendering for the key, evicting the least
+// recently used entries beyond the size of the cache.
+func (c *renderCache) add(key [sha256.Size]byte, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.size <= 0 {
+		return
+	}
+	if e, ok := c.entries[key]; ok {
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&renderCacheEntry{key: key, data: append([]byte(nil), data...)})
+	c.evict()
+}
+
+func (c *renderCache) evict() {
+	for c.order.Len() > c.size {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*renderCacheEntry).key)
+	}
+}
+
+// len returns the number of cached renderings.
+func (c *renderCache) len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.order == nil {
+		return 0
+	}
+	return c.order.Len()
+}
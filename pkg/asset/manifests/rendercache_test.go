@@ -0,0 +1,100 @@
+package manifests
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateCacheHits(t *testing.T) {
+	jobs, templateData := testRenderJobs(t)
+	uncached, err := renderBootkubeTemplates(jobs, templateData, 1)
+	if err != nil {
+		t.Fatalf("failed to render templates: %v", err)
+	}
+
+	SetTemplateCacheSize(len(jobs))
+	defer SetTemplateCacheSize(0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			files, err := renderBootkubeTemplates(jobs, templateData, 2)
+			if assert.NoError(t, err, "unexpected error rendering templates") {
+				assert.Equal(t, uncached, files, "cached renderings differ")
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, len(jobs), templateCache.len(), "unexpected number of cached renderings")
+
+	// Modifying a rendering does not modify the cached copy.
+	files, err := renderBootkubeTemplates(jobs, templateData, 1)
+	if err != nil {
+		t.Fatalf("failed to render templates: %v", err)
+	}
+	files[0].Data[0] = 'x'
+	files, err = renderBootkubeTemplates(jobs, templateData, 1)
+	if assert.NoError(t, err, "unexpected error rendering templates") {
+		assert.Equal(t, uncached, files, "cached renderings were modified")
+	}
+
+	// Different template data is not served from the cache.
+	data := *templateData
+	data.CVOClusterID = "other-cluster-id"
+	rendered, err := applyTemplateData([]byte("cluster: {{ .CVOClusterID }}\n"), &data)
+	if assert.NoError(t, err, "unexpected error rendering template") {
+		assert.Equal(t, "cluster: other-cluster-id\n", string(rendered))
+	}
+}
+
+func TestRenderCacheBounded(t *testing.T) {
+	c := &renderCache{}
+	c.resize(2)
+	key := func(i int) [sha256.Size]byte {
+		return sha256.Sum256([]byte(fmt.Sprintf("key-%d", i)))
+	}
+	c.add(key(1), []byte("one"))
+	c.add(key(2), []byte("two"))
+	_, ok := c.get(key(1))
+	assert.True(t, ok, "first entry missing")
+	c.add(key(3), []byte("three"))
+
+	assert.Equal(t, 2, c.len(), "unexpected number of entries")
+	_, ok = c.get(key(2))
+	assert.False(t, ok, "least recently used entry was not evicted")
+	data, ok := c.get(key(1))
+	if assert.True(t, ok, "recently used entry was evicted") {
+		assert.Equal(t, "one", string(data))
+	}
+
+	c.resize(0)
+	assert.Equal(t, 0, c.len(), "disabled cache holds entries")
+	c.add(key(4), []byte("four"))
+	_, ok = c.get(key(4))
+	assert.False(t, ok, "disabled cache returned an entry")
+}
+
+func BenchmarkRenderBootkubeTemplatesCache(b *testing.B) {
+	jobs, templateData := testRenderJobs(b)
+	for name, size := range map[string]int{
+		"uncached": 0,
+		"cached":   len(jobs),
+	} {
+		size := size
+		b.Run(name, func(b *testing.B) {
+			SetTemplateCacheSize(size)
+			defer SetTemplateCacheSize(0)
+			for i := 0; i < b.N; i++ {
+				if _, err := renderBootkubeTemplates(jobs, templateData, 1); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
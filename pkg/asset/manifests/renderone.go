@@ -0,0 +1,43 @@
+package manifests
+
+import (
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// RenderOne renders the named bootkube manifest, such as etcd-service.yaml
+// or manifests/etcd-service.yaml, with the same template data as Generate.
+// The manifest is returned as rendered from its template, without the
+// provenance annotations or the output format of the generated manifests,
+// and regardless of whether Generate would include it. The dependencies must
+// be the same as for Generate.
+func (m *Manifests) RenderOne(dependencies asset.Parents, filename string) (*asset.File, error) {
+	dir := m.directory()
+	templates := append(bootkubeTemplates(), conditionalBootkubeTemplates()...)
+	for _, a := range templates {
+		dependencies.Get(a)
+		for _, f := range a.Files() {
+			name := bootkubeManifestFilename(dir, f)
+			if filename != name && filename != filepath.Base(name) {
+				continue
+			}
+			templateData, err := m.bootkubeTemplateData(dependencies, false)
+			if err != nil {
+				return nil, err
+			}
+			files, err := renderBootkubeTemplates([]bootkubeRenderJob{{
+				source:   a.Name(),
+				template: f,
+				filename: name,
+			}}, templateData, 1)
+			if err != nil {
+				return nil, err
+			}
+			return files[0], nil
+		}
+	}
+	return nil, errors.Errorf("unknown bootkube manifest %q", filename)
+}
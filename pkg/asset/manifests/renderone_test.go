@@ -0,0 +1,57 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content/bootkube"
+)
+
+func TestManifestsRenderOne(t *testing.T) {
+	m := &Manifests{}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+
+	cases := []struct {
+		name   string
+		source asset.Asset
+	}{
+		{
+			name:   "etcd-service.yaml",
+			source: &bootkube.EtcdService{},
+		},
+		{
+			name:   filepath.Join(manifestDir, "machine-config-server-tls-secret.yaml"),
+			source: &bootkube.MachineConfigServerTLSSecret{},
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			f, err := m.RenderOne(parents, tc.name)
+			if !assert.NoError(t, err, "unexpected error rendering %s", tc.name) {
+				return
+			}
+			filename := filepath.Join(manifestDir, filepath.Base(tc.name))
+			assert.Equal(t, filename, f.Filename)
+			// The generated manifest only adds the provenance annotations.
+			annotated, err := annotateProvenance(f, tc.source.Name())
+			if assert.NoError(t, err) {
+				assert.Equal(t, string(m.FileMap()[filename].Data), string(annotated.Data))
+			}
+		})
+	}
+}
+
+func TestManifestsRenderOneUnknown(t *testing.T) {
+	m := &Manifests{}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	for _, name := range []string{"missing.yaml", "etcd-service.yaml.template", "openshift/etcd-service.yaml"} {
+		_, err := m.RenderOne(parents, name)
+		assert.EqualError(t, err, `unknown bootkube manifest "`+name+`"`)
+	}
+}
@@ -0,0 +1,86 @@
+package manifests
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// resourceQuotaName is the name of the ResourceQuotas of the install-config.
+const resourceQuotaName = "installer-default"
+
+// resourceQuotaFilename returns the name of the ResourceQuota manifest of the
+// namespace.
+func resourceQuotaFilename(dir, namespace string) string {
+	return filepath.Join(dir, fmt.Sprintf("resource-quota-%s.yaml", namespace))
+}
+
+type resourceQuota struct {
+	metav1.TypeMeta `json:",inline"`
+	Metadata        metadata                 `json:"metadata"`
+	Spec            corev1.ResourceQuotaSpec `json:"spec"`
+}
+
+// createdNamespaces returns the namespaces that the Namespace objects of the
+// files create. Documents that do not parse are ignored.
+func createdNamespaces(files []*asset.File) map[string]bool {
+	created := map[string]bool{}
+	for _, f := range files {
+		for _, doc := range documentSeparator.Split(string(f.Data), -1) {
+			var obj namespaceObject
+			if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+				continue
+			}
+			if obj.Kind == "Namespace" {
+				created[obj.Metadata.Name] = true
+			}
+		}
+	}
+	return created
+}
+
+// resourceQuotaFiles returns the ResourceQuota manifests of the quotas, in
+// their order. Each of the namespaces must be one of the created ones.
+func resourceQuotaFiles(quotas []types.NamespaceResourceQuota, created map[string]bool, dir string) ([]*asset.File, error) {
+	files := make([]*asset.File, 0, len(quotas))
+	for _, quota := range quotas {
+		if !created[quota.Namespace] {
+			return nil, errors.Errorf("the installer does not create the %s namespace of the resource quota", quota.Namespace)
+		}
+		hard := make(corev1.ResourceList, len(quota.Hard))
+		for name, value := range quota.Hard {
+			q, err := resource.ParseQuantity(value)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid %s quota of the %s namespace", name, quota.Namespace)
+			}
+			hard[corev1.ResourceName(name)] = q
+		}
+		data, err := yaml.Marshal(&resourceQuota{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: "v1",
+				Kind:       "ResourceQuota",
+			},
+			Metadata: metadata{
+				Name:      resourceQuotaName,
+				Namespace: quota.Namespace,
+			},
+			Spec: corev1.ResourceQuotaSpec{Hard: hard},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create the resource quota of the %s namespace", quota.Namespace)
+		}
+		files = append(files, &asset.File{
+			Filename: resourceQuotaFilename(dir, quota.Namespace),
+			Data:     data,
+		})
+	}
+	return files, nil
+}
@@ -0,0 +1,72 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestManifestsResourceQuotasUnset(t *testing.T) {
+	m := &Manifests{}
+	generateTestAsset(t, testInstallConfig(), m)
+
+	for _, f := range m.FileList {
+		assert.NotContains(t, string(f.Data), "kind: ResourceQuota", "unexpected resource quota in %s", f.Filename)
+	}
+}
+
+func TestManifestsResourceQuotas(t *testing.T) {
+	ic := testInstallConfig()
+	ic.ResourceQuotas = []types.NamespaceResourceQuota{{
+		Namespace: "openshift-machine-config-operator",
+		Hard: map[string]string{
+			"requests.cpu":    "2",
+			"requests.memory": "4096Mi",
+			"pods":            "20",
+		},
+	}}
+	m := &Manifests{}
+	generateTestAsset(t, ic, m)
+
+	f, ok := m.FileMap()[filepath.Join(manifestDir, "resource-quota-openshift-machine-config-operator.yaml")]
+	if !assert.True(t, ok, "the resource quota was not generated") {
+		return
+	}
+	var quota corev1.ResourceQuota
+	if err := yaml.Unmarshal(f.Data, &quota); err != nil {
+		t.Fatalf("failed to unmarshal the resource quota: %v", err)
+	}
+	assert.Equal(t, "ResourceQuota", quota.Kind)
+	assert.Equal(t, "installer-default", quota.Name)
+	assert.Equal(t, "openshift-machine-config-operator", quota.Namespace)
+	assert.Equal(t, corev1.ResourceList{
+		corev1.ResourceRequestsCPU:    resource.MustParse("2"),
+		corev1.ResourceRequestsMemory: resource.MustParse("4Gi"),
+		corev1.ResourcePods:           resource.MustParse("20"),
+	}, quota.Spec.Hard)
+	assert.Contains(t, string(f.Data), "requests.memory: 4Gi")
+
+	// Only the one namespace has a quota.
+	for _, f := range m.FileList {
+		if filepath.Base(f.Filename) != "resource-quota-openshift-machine-config-operator.yaml" {
+			assert.NotContains(t, string(f.Data), "kind: ResourceQuota", "unexpected resource quota in %s", f.Filename)
+		}
+	}
+}
+
+func TestManifestsResourceQuotasUnknownNamespace(t *testing.T) {
+	ic := testInstallConfig()
+	ic.ResourceQuotas = []types.NamespaceResourceQuota{{
+		Namespace: "kube-system",
+		Hard:      map[string]string{"pods": "20"},
+	}}
+	m := &Manifests{}
+	err := m.Generate(generateTestParents(t, ic, m))
+	assert.EqualError(t, err, "the installer does not create the kube-system namespace of the resource quota")
+}
@@ -0,0 +1,161 @@
+package manifests
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// sealedSecret is a Bitnami SealedSecret.
+type sealedSecret struct {
+	metav1.TypeMeta `json:",inline"`
+	Metadata        metadata         `json:"metadata"`
+	Spec            sealedSecretSpec `json:"spec"`
+}
+
+type sealedSecretSpec struct {
+	Template      sealedSecretTemplate `json:"template"`
+	EncryptedData map[string]string    `json:"encryptedData"`
+}
+
+type sealedSecretTemplate struct {
+	Metadata metadata          `json:"metadata"`
+	Type     corev1.SecretType `json:"type,omitempty"`
+}
+
+// sealSecrets returns the files with each Secret replaced by a SealedSecret
+// sealed with the key, recording each sealed file under the source of the
+// secret. Files that do not hold a single Secret are returned unchanged, and
+// so are the mandatoryBootkubeManifests: the cluster is bootstrapped with
+// them before a Sealed Secrets controller can run to unseal them.
+func sealSecrets(files []*asset.File, key *rsa.PublicKey, sources map[*asset.File]string) ([]*asset.File, error) {
+	sealed := make([]*asset.File, len(files))
+	for i, f := range files {
+		if mandatoryBootkubeManifests[filepath.Base(f.Filename)] {
+			sealed[i] = f
+			continue
+		}
+		s, err := sealSecretFile(f, key)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to seal %s", f.Filename)
+		}
+		if sources != nil {
+			sources[s] = sources[f]
+		}
+		sealed[i] = s
+	}
+	return sealed, nil
+}
+
+func sealSecretFile(f *asset.File, key *rsa.PublicKey) (*asset.File, error) {
+	docs := 0
+	for _, doc := range documentSeparator.Split(string(f.Data), -1) {
+		if strings.TrimSpace(doc) != "" {
+			docs++
+		}
+	}
+	if docs != 1 {
+		return f, nil
+	}
+	var typeMeta metav1.TypeMeta
+	if err := yaml.Unmarshal(f.Data, &typeMeta); err != nil || typeMeta.APIVersion != "v1" || typeMeta.Kind != "Secret" {
+		return f, nil
+	}
+	var secret corev1.Secret
+	if err := yaml.Unmarshal(f.Data, &secret); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string][]byte, len(secret.Data)+len(secret.StringData))
+	for k, v := range secret.Data {
+		values[k] = v
+	}
+	for k, v := range secret.StringData {
+		values[k] = []byte(v)
+	}
+	// The secrets are sealed with the default, strict scope, so they can
+	// only be unsealed under their own name and namespace.
+	label := []byte(secret.Namespace + "/" + secret.Name)
+	encrypted := make(map[string]string, len(values))
+	for k, v := range values {
+		ciphertext, err := hybridEncrypt(key, v, label)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to encrypt %s", k)
+		}
+		encrypted[k] = base64.StdEncoding.EncodeToString(ciphertext)
+	}
+
+	data, err := yaml.Marshal(&sealedSecret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: "bitnami.com/v1alpha1",
+			Kind:       "SealedSecret",
+		},
+		Metadata: metadata{
+			Name:        secret.Name,
+			Namespace:   secret.Namespace,
+			Labels:      secret.Labels,
+			Annotations: secret.Annotations,
+		},
+		Spec: sealedSecretSpec{
+			Template: sealedSecretTemplate{
+				Metadata: metadata{
+					Name:        secret.Name,
+					Namespace:   secret.Namespace,
+					Labels:      secret.Labels,
+					Annotations: secret.Annotations,
+				},
+				Type: secret.Type,
+			},
+			EncryptedData: encrypted,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &asset.File{
+		Filename: f.Filename,
+		Data:     data,
+	}, nil
+}
+
+// hybridEncrypt encrypts the plaintext as the sealed-secrets controller
+// expects: with a random AES-256-GCM session key, which is itself encrypted
+// with RSA-OAEP and prefixed to the ciphertext along with its length.
+func hybridEncrypt(key *rsa.PublicKey, plaintext, label []byte) ([]byte, error) {
+	sessionKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, sessionKey); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(sessionKey)
+	if err != nil {
+		return nil, err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, err
+	}
+	encryptedKey, err := rsa.EncryptOAEP(sha256.New(), rand.Reader, key, sessionKey, label)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]byte, 2, 2+len(encryptedKey)+len(plaintext)+aead.Overhead())
+	binary.BigEndian.PutUint16(out, uint16(len(encryptedKey)))
+	out = append(out, encryptedKey...)
+	// Each session key is used once, so a zero nonce is safe.
+	return aead.Seal(out, make([]byte, aead.NonceSize()), plaintext, nil), nil
+}
@@ -0,0 +1,190 @@
+package manifests
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func generateSealingKey(t *testing.T) *rsa.PrivateKey {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate sealing key: %v", err)
+	}
+	return key
+}
+
+// unseal decrypts the values of the SealedSecret as the sealed-secrets
+// controller does.
+func unseal(t *testing.T, key *rsa.PrivateKey, sealed *sealedSecret) map[string][]byte {
+	label := []byte(sealed.Metadata.Namespace + "/" + sealed.Metadata.Name)
+	values := map[string][]byte{}
+	for k, v := range sealed.Spec.EncryptedData {
+		ciphertext, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			t.Fatalf("%s is not base64-encoded: %v", k, err)
+		}
+		keyLen := int(binary.BigEndian.Uint16(ciphertext))
+		sessionKey, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, key, ciphertext[2:2+keyLen], label)
+		if err != nil {
+			t.Fatalf("failed to decrypt the session key of %s: %v", k, err)
+		}
+		block, err := aes.NewCipher(sessionKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			t.Fatal(err)
+		}
+		plaintext, err := aead.Open(nil, make([]byte, aead.NonceSize()), ciphertext[2+keyLen:], nil)
+		if err != nil {
+			t.Fatalf("failed to decrypt %s: %v", k, err)
+		}
+		values[k] = plaintext
+	}
+	return values
+}
+
+func TestSealSecretFile(t *testing.T) {
+	key := generateSealingKey(t)
+	secret := &asset.File{
+		Filename: "manifests/test-secret.yaml",
+		Data: []byte(`apiVersion: v1
+kind: Secret
+metadata:
+  name: test-secret
+  namespace: test-namespace
+  labels:
+    app: test
+type: kubernetes.io/tls
+data:
+  tls.crt: ` + base64.StdEncoding.EncodeToString([]byte("certificate")) + `
+stringData:
+  tls.key: key
+`),
+	}
+
+	f, err := sealSecretFile(secret, &key.PublicKey)
+	if !assert.NoError(t, err, "unexpected error") {
+		return
+	}
+	assert.Equal(t, secret.Filename, f.Filename)
+	var sealed sealedSecret
+	if err := yaml.Unmarshal(f.Data, &sealed); err != nil {
+		t.Fatalf("failed to unmarshal the SealedSecret: %v", err)
+	}
+	assert.Equal(t, "bitnami.com/v1alpha1", sealed.APIVersion)
+	assert.Equal(t, "SealedSecret", sealed.Kind)
+	expectedMetadata := metadata{
+		Name:      "test-secret",
+		Namespace: "test-namespace",
+		Labels:    map[string]string{"app": "test"},
+	}
+	assert.Equal(t, expectedMetadata, sealed.Metadata)
+	assert.Equal(t, expectedMetadata, sealed.Spec.Template.Metadata)
+	assert.Equal(t, corev1.SecretTypeTLS, sealed.Spec.Template.Type)
+	assert.NotContains(t, string(f.Data), "certificate", "the secret was not encrypted")
+	assert.Equal(t, map[string][]byte{
+		"tls.crt": []byte("certificate"),
+		"tls.key": []byte("key"),
+	}, unseal(t, key, &sealed))
+
+	// The values cannot be unsealed under another name.
+	sealed.Metadata.Name = "other-secret"
+	for _, v := range sealed.Spec.EncryptedData {
+		ciphertext, _ := base64.StdEncoding.DecodeString(v)
+		keyLen := int(binary.BigEndian.Uint16(ciphertext))
+		_, err := rsa.DecryptOAEP(sha256.New(), rand.Reader, key, ciphertext[2:2+keyLen], []byte("test-namespace/other-secret"))
+		assert.Error(t, err, "the secret was not sealed with the strict scope")
+	}
+}
+
+func TestSealSecretFilePassThrough(t *testing.T) {
+	key := generateSealingKey(t)
+	for _, data := range []string{
+		"apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n",
+		"apiVersion: v1\nkind: Secret\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: b\n",
+		"not: [valid\n",
+	} {
+		f := &asset.File{Filename: "manifests/test.yaml", Data: []byte(data)}
+		sealed, err := sealSecretFile(f, &key.PublicKey)
+		if assert.NoError(t, err, "unexpected error for %q", data) {
+			assert.True(t, f == sealed, "%q was unexpectedly sealed", data)
+		}
+	}
+}
+
+func TestManifestsSealedSecrets(t *testing.T) {
+	ic := testInstallConfig()
+	ic.EtcdBackup = &types.EtcdBackup{Schedule: "0 0 * * *", Image: "quay.io/openshift/etcd-backup:latest"}
+	plain := &Manifests{}
+	parents := generateTestParents(t, ic, plain)
+	if err := plain.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+
+	key := generateSealingKey(t)
+	m := &Manifests{SealedSecretsKey: &key.PublicKey}
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate sealed manifests: %v", err)
+	}
+
+	sealedFiles := m.FileMap()
+	sealedCount := 0
+	for _, f := range plain.FileList {
+		var secret corev1.Secret
+		if err := yaml.Unmarshal(f.Data, &secret); err != nil || secret.Kind != "Secret" {
+			continue
+		}
+		name := filepath.Base(f.Filename)
+		// The cluster is bootstrapped with these before they could be unsealed.
+		if mandatoryBootkubeManifests[name] {
+			assert.Equal(t, string(f.Data), string(sealedFiles[f.Filename].Data), "%s was sealed", name)
+			continue
+		}
+		sealedCount++
+		var sealed sealedSecret
+		if err := yaml.Unmarshal(sealedFiles[f.Filename].Data, &sealed); err != nil {
+			t.Fatalf("failed to unmarshal the sealed %s: %v", name, err)
+		}
+		if !assert.Equal(t, "SealedSecret", sealed.Kind, "%s was not sealed", name) {
+			continue
+		}
+		assert.Equal(t, secret.Name, sealed.Metadata.Name, "unexpected name for %s", name)
+		assert.Equal(t, secret.Namespace, sealed.Metadata.Namespace, "unexpected namespace for %s", name)
+		expected := map[string]string{}
+		for k, v := range secret.Data {
+			expected[k] = string(v)
+		}
+		for k, v := range secret.StringData {
+			expected[k] = v
+		}
+		values := unseal(t, key, &sealed)
+		assert.Equal(t, len(expected), len(values), "unexpected keys in %s", name)
+		for k, v := range expected {
+			assert.Equal(t, v, string(values[k]), "unexpected value for %s in %s", k, name)
+		}
+	}
+
+	assert.NotZero(t, sealedCount, "no secret was sealed")
+
+	// Other manifests are unchanged.
+	for _, name := range []string{"etcd-namespace.yaml", "etcd-ca-bundle-configmap.yaml"} {
+		filename := filepath.Join(manifestDir, name)
+		assert.Equal(t, plain.FileMap()[filename].Data, sealedFiles[filename].Data, "%s was modified", name)
+	}
+}
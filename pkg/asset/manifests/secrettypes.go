@@ -0,0 +1,51 @@
+package manifests
+
+import (
+	"fmt"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// expectedSecretTypes are the types of the generated Secrets whose consumers
+// depend on them, by namespace/name.
+var expectedSecretTypes = map[string]corev1.SecretType{
+	"openshift-config/etcd-client":                                corev1.SecretTypeTLS,
+	"openshift-config/etcd-metric-client":                         corev1.SecretTypeTLS,
+	"openshift-config/etcd-metric-signer":                         corev1.SecretTypeTLS,
+	"openshift-config/etcd-signer":                                corev1.SecretTypeTLS,
+	"openshift-config/pull-secret":                                corev1.SecretTypeDockerConfigJson,
+	"openshift-etcd/etcd-backup-client":                           corev1.SecretTypeTLS,
+	"openshift-etcd/etcd-metric-client":                           corev1.SecretTypeTLS,
+	"openshift-machine-config-operator/machine-config-server-tls": corev1.SecretTypeTLS,
+	"openshift-monitoring/alertmanager-main":                      corev1.SecretTypeOpaque,
+}
+
+// verifySecretTypes returns an error if a Secret of the files has no type,
+// or has a type other than that expectedSecretTypes records for it.
+func verifySecretTypes(files []*asset.File) error {
+	for _, f := range files {
+		for _, doc := range documentSeparator.Split(string(f.Data), -1) {
+			var secret struct {
+				APIVersion string            `json:"apiVersion"`
+				Kind       string            `json:"kind"`
+				Metadata   metadata          `json:"metadata"`
+				Type       corev1.SecretType `json:"type"`
+			}
+			if err := yaml.Unmarshal([]byte(doc), &secret); err != nil || secret.APIVersion != "v1" || secret.Kind != "Secret" {
+				continue
+			}
+			name := fmt.Sprintf("%s/%s", secret.Metadata.Namespace, secret.Metadata.Name)
+			if secret.Type == "" {
+				return errors.Errorf("%s: the %s secret has no type", f.Filename, name)
+			}
+			if expected, ok := expectedSecretTypes[name]; ok && secret.Type != expected {
+				return errors.Errorf("%s: the %s secret is of type %q instead of %q", f.Filename, name, secret.Type, expected)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestVerifySecretTypes(t *testing.T) {
+	cases := []struct {
+		name          string
+		data          string
+		expectedError string
+	}{
+		{
+			name: "expected type",
+			data: "apiVersion: v1\nkind: Secret\nmetadata:\n  name: pull-secret\n  namespace: openshift-config\ntype: kubernetes.io/dockerconfigjson\n",
+		},
+		{
+			name: "other secret",
+			data: "apiVersion: v1\nkind: Secret\nmetadata:\n  name: other\n  namespace: openshift-config\ntype: Opaque\n",
+		},
+		{
+			name: "not a secret",
+			data: "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: pull-secret\n  namespace: openshift-config\n",
+		},
+		{
+			name:          "unexpected type",
+			data:          "apiVersion: v1\nkind: Secret\nmetadata:\n  name: machine-config-server-tls\n  namespace: openshift-machine-config-operator\ntype: Opaque\n",
+			expectedError: `manifests/test.yaml: the openshift-machine-config-operator/machine-config-server-tls secret is of type "Opaque" instead of "kubernetes.io/tls"`,
+		},
+		{
+			name:          "no type",
+			data:          "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: a\n---\napiVersion: v1\nkind: Secret\nmetadata:\n  name: other\n  namespace: openshift-config\n",
+			expectedError: "manifests/test.yaml: the openshift-config/other secret has no type",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := verifySecretTypes([]*asset.File{{Filename: "manifests/test.yaml", Data: []byte(tc.data)}})
+			if tc.expectedError == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
+
+func TestManifestsSecretTypes(t *testing.T) {
+	m := &Manifests{}
+	generateTestAsset(t, testInstallConfig(), m)
+
+	files := m.FileMap()
+	for name, expected := range map[string]corev1.SecretType{
+		"openshift-config-secret-pull-secret.yaml": corev1.SecretTypeDockerConfigJson,
+		"machine-config-server-tls-secret.yaml":    corev1.SecretTypeTLS,
+		"etcd-signer-secret.yaml":                  corev1.SecretTypeTLS,
+	} {
+		var secret corev1.Secret
+		if err := yaml.Unmarshal(files[filepath.Join(manifestDir, name)].Data, &secret); err != nil {
+			t.Fatalf("failed to unmarshal %s: %v", name, err)
+		}
+		assert.Equal(t, expected, secret.Type, "unexpected type for %s", name)
+	}
+}
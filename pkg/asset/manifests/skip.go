@@ -0,0 +1,74 @@
+package manifests
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// skippedManifestsAnnotation records on the cluster-config configmap which
+// bootkube manifests were skipped.
+const skippedManifestsAnnotation = "installer.openshift.io/skipped-manifests"
+
+// mandatoryBootkubeManifests are the bootkube manifests that cannot be
+// skipped, since the cluster cannot bootstrap without them.
+var mandatoryBootkubeManifests = map[string]bool{
+	"04-openshift-machine-config-operator.yaml": true,
+	"cvo-overrides.yaml":                        true,
+	"etcd-ca-bundle-configmap.yaml":             true,
+	"etcd-client-secret.yaml":                   true,
+	"etcd-metric-client-secret.yaml":            true,
+	"etcd-metric-serving-ca-configmap.yaml":     true,
+	"etcd-metric-signer-secret.yaml":            true,
+	"etcd-namespace.yaml":                       true,
+	"etcd-serving-ca-configmap.yaml":            true,
+	"etcd-signer-secret.yaml":                   true,
+	"kube-system-configmap-root-ca.yaml":        true,
+	"machine-config-server-tls-secret.yaml":     true,
+	"openshift-config-secret-pull-secret.yaml":  true,
+}
+
+// skippedBootkubeManifests returns the set of SkippedManifests, checking that
+// each of them names one of the optional manifests rendered from the
+// templates.
+func (m *Manifests) skippedBootkubeManifests(templates []asset.WritableAsset) (map[string]bool, error) {
+	if len(m.SkippedManifests) == 0 {
+		return nil, nil
+	}
+	known := map[string]bool{}
+	for _, a := range templates {
+		for _, f := range a.Files() {
+			known[filepath.Base(bootkubeManifestFilename("", f))] = true
+		}
+	}
+	skipped := make(map[string]bool, len(m.SkippedManifests))
+	for _, name := range m.SkippedManifests {
+		if mandatoryBootkubeManifests[name] {
+			return nil, errors.Errorf("%s is required by the installer and cannot be skipped", name)
+		}
+		if !known[name] {
+			return nil, errors.Errorf("cannot skip %s, which is not a bootkube manifest", name)
+		}
+		skipped[name] = true
+	}
+	return skipped, nil
+}
+
+// skippedManifestsAnnotationValue returns the value of the skipped-manifests
+// annotation, or an empty string when no manifest is skipped.
+func (m *Manifests) skippedManifestsAnnotationValue() string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, name := range m.SkippedManifests {
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return strings.Join(names, ",")
+}
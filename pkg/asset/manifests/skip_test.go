@@ -0,0 +1,80 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/ghodss/yaml"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManifestsSkippedManifests(t *testing.T) {
+	cases := []struct {
+		name                string
+		skipped             []string
+		expectedError       string
+		expectedAnnotation  string
+		expectedNotIncluded []string
+	}{
+		{
+			name:                "optional",
+			skipped:             []string{"etcd-service.yaml", "etcd-host-service.yaml", "etcd-service.yaml"},
+			expectedAnnotation:  "etcd-host-service.yaml,etcd-service.yaml",
+			expectedNotIncluded: []string{"manifests/etcd-service.yaml", "manifests/etcd-host-service.yaml"},
+		},
+		{
+			name:               "conditional manifest that is not generated",
+			skipped:            []string{"etcd-pod-disruption-budget.yaml"},
+			expectedAnnotation: "etcd-pod-disruption-budget.yaml",
+		},
+		{
+			name:          "mandatory",
+			skipped:       []string{"etcd-service.yaml", "etcd-signer-secret.yaml"},
+			expectedError: "etcd-signer-secret.yaml is required by the installer and cannot be skipped",
+		},
+		{
+			name:          "unknown",
+			skipped:       []string{"cluster-config.yaml"},
+			expectedError: "cannot skip cluster-config.yaml, which is not a bootkube manifest",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			m := &Manifests{SkippedManifests: tc.skipped}
+			err := m.Generate(generateTestParents(t, testInstallConfig(), m))
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			if !assert.NoError(t, err, "unexpected error") {
+				return
+			}
+
+			filenames := map[string]bool{}
+			var kubeSysConfigData []byte
+			for _, f := range m.FileList {
+				filenames[f.Filename] = true
+				if f.Filename == kubeSysConfigPath {
+					kubeSysConfigData = f.Data
+				}
+			}
+			for _, filename := range tc.expectedNotIncluded {
+				assert.False(t, filenames[filename], "%s was not skipped", filename)
+			}
+			expected, err := m.ExpectedFilenames()
+			if assert.NoError(t, err, "unexpected error from ExpectedFilenames") {
+				for _, filename := range expected {
+					assert.True(t, filenames[filename], "expected %s was not generated", filename)
+				}
+			}
+
+			var obj struct {
+				Metadata struct {
+					Annotations map[string]string `json:"annotations"`
+				} `json:"metadata"`
+			}
+			if assert.NoError(t, yaml.Unmarshal(kubeSysConfigData, &obj), "failed to parse the cluster-config configmap") {
+				assert.Equal(t, tc.expectedAnnotation, obj.Metadata.Annotations[skippedManifestsAnnotation])
+			}
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package manifests
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// StaleFiles compares the manifests, typically loaded from disk, with those
+// Generate would produce from the dependencies, such as after the
+// install-config was edited, so that tooling can warn before reusing
+// outdated files. The dependencies must be the same as for Generate, with
+// the cluster ID and TLS assets those the manifests were generated with,
+// as kept in the state file, so that only the changes of the other
+// dependencies are found. Modified lists the stale files, Removed the files
+// that would no longer be generated and Added those that would be. Files
+// holding the same objects are not stale, however their YAML is formatted,
+// and the checksums file is ignored. Sealed secrets are encrypted anew on
+// each generation, so they are always stale. m is left unchanged.
+func (m *Manifests) StaleFiles(dependencies asset.Parents) (*Differences, error) {
+	regenerated := m.DeepCopy()
+	regenerated.FileList, regenerated.KubeSysConfig = nil, nil
+	if err := regenerated.Generate(dependencies); err != nil {
+		return nil, errors.Wrapf(err, "failed to regenerate %s", m.Name())
+	}
+
+	loaded, generated := filesByName(m.FileList), filesByName(regenerated.FileList)
+	for _, files := range []map[string]*asset.File{loaded, generated} {
+		for name, f := range files {
+			if m.IsChecksumsFile(f) {
+				delete(files, name)
+			}
+		}
+	}
+	return diffFiles(loaded, generated, sameObjects)
+}
+
+// sameObjects returns whether the two YAML or JSON streams hold the same
+// objects. Streams that do not parse are compared byte for byte.
+func sameObjects(a, b []byte) bool {
+	if string(a) == string(b) {
+		return true
+	}
+	aObjects, err := parseObjects(a)
+	if err != nil {
+		return false
+	}
+	bObjects, err := parseObjects(b)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(aObjects, bObjects)
+}
+
+// parseObjects returns the objects of the non-empty documents of the stream.
+func parseObjects(data []byte) ([]interface{}, error) {
+	objects := []interface{}{}
+	for _, doc := range documentSeparator.Split(string(data), -1) {
+		if strings.TrimSpace(doc) == "" {
+			continue
+		}
+		var object interface{}
+		if err := yaml.Unmarshal([]byte(doc), &object); err != nil {
+			return nil, err
+		}
+		objects = append(objects, object)
+	}
+	return objects, nil
+}
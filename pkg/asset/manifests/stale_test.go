@@ -0,0 +1,184 @@
+package manifests
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/asset/tls"
+	"github.com/openshift/installer/pkg/types"
+)
+
+// reusedParents generates the parents of the manifests for the
+// install-config, reusing the cluster ID and TLS assets of the previous
+// parents as they are reused from the state file.
+func reusedParents(t *testing.T, ic *types.InstallConfig, m *Manifests, previous asset.Parents) asset.Parents {
+	generated := map[reflect.Type]asset.Asset{
+		reflect.TypeOf(&installconfig.InstallConfig{}): &installconfig.InstallConfig{Config: ic},
+	}
+	for _, a := range []asset.Asset{
+		&installconfig.ClusterID{},
+		&tls.RootCA{},
+		&tls.EtcdSignerCertKey{},
+		&tls.EtcdCABundle{},
+		&tls.EtcdSignerClientCertKey{},
+		&tls.EtcdMetricCABundle{},
+		&tls.EtcdMetricSignerCertKey{},
+		&tls.EtcdMetricSignerClientCertKey{},
+		&tls.MCSCertKey{},
+	} {
+		previous.Get(a)
+		generated[reflect.TypeOf(a)] = a
+	}
+	var generate func(a asset.Asset) asset.Asset
+	generate = func(a asset.Asset) asset.Asset {
+		if g, ok := generated[reflect.TypeOf(a)]; ok {
+			return g
+		}
+		parents := asset.Parents{}
+		for _, d := range a.Dependencies() {
+			parents.Add(generate(d))
+		}
+		if err := a.Generate(parents); err != nil {
+			t.Fatalf("failed to generate %s: %v", a.Name(), err)
+		}
+		generated[reflect.TypeOf(a)] = a
+		return a
+	}
+
+	parents := asset.Parents{}
+	for _, d := range m.Dependencies() {
+		parents.Add(generate(d))
+	}
+	return parents
+}
+
+func TestStaleFiles(t *testing.T) {
+	ic := testInstallConfig()
+	m := &Manifests{}
+	parents := generateTestParents(t, ic, m)
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+
+	stale, err := m.StaleFiles(reusedParents(t, ic, m, parents))
+	if assert.NoError(t, err, "unexpected error") {
+		assert.True(t, stale.Empty(), "unexpected stale files for the same install-config: %v", stale.Modified)
+	}
+
+	edited := testInstallConfig()
+	edited.EtcdMemberHostnames = []string{"etcd-a"}
+	edited.EtcdBackup = &types.EtcdBackup{Schedule: "0 0 * * *", Image: "quay.io/openshift/etcd-backup:latest"}
+	stale, err = m.StaleFiles(reusedParents(t, edited, m, parents))
+	if !assert.NoError(t, err, "unexpected error") {
+		return
+	}
+	assert.Equal(t, []string{
+		filepath.Join(manifestDir, "cluster-config.yaml"),
+		filepath.Join(manifestDir, "etcd-host-service-endpoints.yaml"),
+	}, stale.Modified)
+	assert.Contains(t, stale.Added, filepath.Join(manifestDir, "etcd-backup-cronjob.yaml"))
+	assert.Equal(t, []string{}, stale.Removed)
+	assert.Contains(t, stale.Diffs[filepath.Join(manifestDir, "etcd-host-service-endpoints.yaml")], "+  - hostname: etcd-a")
+}
+
+func TestStaleFilesDirectory(t *testing.T) {
+	ic := testInstallConfig()
+	m := &Manifests{Directory: "custom-manifests"}
+	parents := generateTestParents(t, ic, m)
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+
+	// The checksums file in the custom directory is ignored too.
+	edited := testInstallConfig()
+	edited.EtcdMemberHostnames = []string{"etcd-a"}
+	stale, err := m.StaleFiles(reusedParents(t, edited, m, parents))
+	if !assert.NoError(t, err, "unexpected error") {
+		return
+	}
+	assert.Equal(t, []string{
+		filepath.Join("custom-manifests", "cluster-config.yaml"),
+		filepath.Join("custom-manifests", "etcd-host-service-endpoints.yaml"),
+	}, stale.Modified)
+	assert.Equal(t, []string{}, stale.Added)
+	assert.Equal(t, []string{}, stale.Removed)
+}
+
+func TestStaleFilesFormatting(t *testing.T) {
+	ic := testInstallConfig()
+	m := &Manifests{}
+	parents := generateTestParents(t, ic, m)
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+
+	// The same objects in JSON are not stale.
+	files, err := convertFiles(m.FileList, FormatJSON)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := m.DeepCopy()
+	loaded.FileList = files
+	stale, err := loaded.StaleFiles(reusedParents(t, ic, m, parents))
+	if assert.NoError(t, err, "unexpected error") {
+		assert.True(t, stale.Empty(), "unexpected stale files: %v", stale.Modified)
+	}
+}
+
+func TestSameObjects(t *testing.T) {
+	cases := []struct {
+		name     string
+		a, b     string
+		expected bool
+	}{
+		{
+			name:     "reindented",
+			a:        "kind: A\nspec:\n  replicas: 3\n",
+			b:        "kind: A\nspec:\n    replicas: 3\n",
+			expected: true,
+		},
+		{
+			name:     "reordered keys and comments",
+			a:        "kind: A\nname: a\n",
+			b:        "# comment\nname: 'a'\nkind: A\n",
+			expected: true,
+		},
+		{
+			name:     "JSON",
+			a:        "kind: A\nlist:\n- 1\n- 2\n",
+			b:        `{"kind": "A", "list": [1, 2]}`,
+			expected: true,
+		},
+		{
+			name:     "extra empty document",
+			a:        "kind: A\n",
+			b:        "---\nkind: A\n---\n",
+			expected: true,
+		},
+		{
+			name: "changed value",
+			a:    "kind: A\nspec:\n  replicas: 3\n",
+			b:    "kind: A\nspec:\n  replicas: 1\n",
+		},
+		{
+			name: "reordered documents",
+			a:    "kind: A\n---\nkind: B\n",
+			b:    "kind: B\n---\nkind: A\n",
+		},
+		{
+			name: "invalid YAML",
+			a:    "kind: [A\n",
+			b:    "kind: [A \n",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, sameObjects([]byte(tc.a), []byte(tc.b)))
+		})
+	}
+}
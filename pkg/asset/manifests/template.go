@@ -1,5 +1,9 @@
 package manifests
 
+import (
+	configv1 "github.com/openshift/api/config/v1"
+)
+
 // AwsCredsSecretData holds encoded credentials and is used to generate cloud-creds secret
 type AwsCredsSecretData struct {
 	Base64encodeAccessKeyID     string
@@ -43,25 +47,44 @@ type cloudCredsSecretData struct {
 	VSphere   *VSphereCredsSecretData
 }
 
-type bootkubeTemplateData struct {
-	CVOClusterID               string
-	EtcdCaBundle               string
-	EtcdEndpointDNSSuffix      string
-	EtcdEndpointHostnames      []string
-	EtcdMetricCaCert           string
-	EtcdMetricSignerCert       string
-	EtcdMetricSignerClientCert string
-	EtcdMetricSignerClientKey  string
-	EtcdMetricSignerKey        string
-	EtcdSignerCert             string
-	EtcdSignerClientCert       string
-	EtcdSignerClientKey        string
-	EtcdSignerKey              string
-	McsTLSCert                 string
-	McsTLSKey                  string
-	PullSecretBase64           string
-	RootCaCert                 string
-	WorkerIgnConfig            string
+// BootkubeTemplateData is the data used to render the bootkube templates.
+type BootkubeTemplateData struct {
+	// APIVersions are the apiVersions of the objects that differ between
+	// Kubernetes releases.
+	APIVersions KubernetesAPIVersions
+	// AlertmanagerConfigBase64 is empty unless the install-config sets an
+	// alert receiver.
+	AlertmanagerConfigBase64 string
+	// ControlPlaneTopology is SingleReplica for single-node control planes
+	// and HighlyAvailable otherwise.
+	ControlPlaneTopology        string
+	CVOClusterID                string
+	CVOOverrides                []configv1.ComponentOverride
+	EtcdBackupImage             string
+	EtcdBackupSchedule          string
+	EtcdCaBundle                string
+	EtcdClusterMonitoring       bool
+	EtcdEndpointDNSSuffix       string
+	EtcdEndpointAddressPrefixes []string
+	EtcdEndpointHostnames       []string
+	EtcdMetricCaCert            string
+	EtcdMetricSignerCert        string
+	EtcdMetricSignerClientCert  string
+	EtcdMetricSignerClientKey   string
+	EtcdMetricSignerKey         string
+	EtcdMinAvailable            int
+	EtcdSignerCert              string
+	EtcdSignerClientCert        string
+	EtcdSignerClientKey         string
+	EtcdSignerKey               string
+	// ExternalPullSecret, when set, references the pull secret instead of
+	// PullSecretBase64 embedding it.
+	ExternalPullSecret *ExternalPullSecretReference
+	McsTLSCert         string
+	McsTLSKey          string
+	PullSecretBase64   string
+	RootCaCert         string
+	WorkerIgnConfig    string
 }
 
 type openshiftTemplateData struct {
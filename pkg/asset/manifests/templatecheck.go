@@ -0,0 +1,32 @@
+package manifests
+
+import (
+	"text/template"
+
+	"github.com/pkg/errors"
+	utilerrors "k8s.io/apimachinery/pkg/util/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// VerifyBootkubeTemplates parses the templates of all of the bootkube
+// template assets, including the registered ones and those only rendered
+// for some install-configs, without executing them. It returns an error
+// listing each template that fails to parse, so that a broken template is
+// found when the installer starts rather than when its manifest is rendered.
+func VerifyBootkubeTemplates() error {
+	var errs []error
+	for _, a := range append(bootkubeTemplates(), conditionalBootkubeTemplates()...) {
+		if err := a.Generate(asset.Parents{}); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to load the templates of %s", a.Name()))
+			continue
+		}
+		for _, f := range a.Files() {
+			left, right := templateDelimiters(f.Filename)
+			if _, err := template.New(f.Filename).Delims(left, right).Funcs(customTmplFuncs).Parse(string(f.Data)); err != nil {
+				errs = append(errs, errors.Wrapf(err, "failed to parse %s of %s", f.Filename, a.Name()))
+			}
+		}
+	}
+	return utilerrors.NewAggregate(errs)
+}
@@ -0,0 +1,50 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// brokenBootkubeTemplate is a bootkube template asset whose template does
+// not parse.
+type brokenBootkubeTemplate struct {
+	FileList []*asset.File
+}
+
+func (t *brokenBootkubeTemplate) Dependencies() []asset.Asset { return []asset.Asset{} }
+
+func (t *brokenBootkubeTemplate) Name() string { return "BrokenBootkubeTemplate" }
+
+func (t *brokenBootkubeTemplate) Generate(asset.Parents) error {
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join("templates", "test-broken.yaml.template"),
+			Data:     []byte("clusterID: {{ .CVOClusterID\n"),
+		},
+	}
+	return nil
+}
+
+func (t *brokenBootkubeTemplate) Files() []*asset.File { return t.FileList }
+
+func (t *brokenBootkubeTemplate) Load(asset.FileFetcher) (bool, error) { return false, nil }
+
+func TestVerifyBootkubeTemplates(t *testing.T) {
+	assert.NoError(t, VerifyBootkubeTemplates(), "unexpected error for the built-in templates")
+
+	defer func(registered []func() asset.WritableAsset) {
+		registeredBootkubeTemplates = registered
+	}(registeredBootkubeTemplates)
+	RegisterBootkubeTemplate(func() asset.WritableAsset { return &testBootkubeTemplate{} })
+	RegisterBootkubeTemplate(func() asset.WritableAsset { return &brokenBootkubeTemplate{} })
+
+	err := VerifyBootkubeTemplates()
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "failed to parse templates/test-broken.yaml.template of BrokenBootkubeTemplate")
+		assert.NotContains(t, err.Error(), "test-registered.yaml.template")
+	}
+}
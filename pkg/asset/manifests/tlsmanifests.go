@@ -0,0 +1,128 @@
+package manifests
+
+import (
+	"path/filepath"
+	"regexp"
+	"runtime"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+)
+
+// tlsTemplateFields matches references to the bootkube template data fields
+// that are derived from the TLS assets.
+var tlsTemplateFields = regexp.MustCompile(`\.(EtcdCaBundle|EtcdMetricCaCert|EtcdMetricSignerCert|EtcdMetricSignerClientCert|EtcdMetricSignerClientKey|EtcdMetricSignerKey|EtcdSignerCert|EtcdSignerClientCert|EtcdSignerClientKey|EtcdSignerKey|McsTLSCert|McsTLSKey|RootCaCert)\b`)
+
+// RegenerateTLSManifests re-renders the bootkube manifests that consume the
+// TLS assets, e.g. after the certificates were rotated, and replaces them in
+// FileList. The conditional manifests are only re-rendered when FileList
+// holds them. The other manifests are left untouched, apart from the
+// checksums file, which is updated to match. The dependencies must be the
+// same as for Generate. With PlaceholderTLS, the placeholders are rendered
+// again, as they are by Generate.
+func (m *Manifests) RegenerateTLSManifests(dependencies asset.Parents) error {
+	if len(m.FileList) == 0 {
+		return errors.New("the manifests have not been generated")
+	}
+
+	templateData, err := m.bootkubeTemplateData(dependencies, false)
+	if err != nil {
+		return err
+	}
+
+	skipped := map[string]bool{}
+	for _, name := range m.SkippedManifests {
+		skipped[name] = true
+	}
+
+	installConfig := &installconfig.InstallConfig{}
+	dependencies.Get(installConfig)
+
+	dir := m.directory()
+	conditional := map[string]bool{}
+	for _, a := range conditionalBootkubeTemplates() {
+		conditional[a.Name()] = true
+	}
+	generated := filesByName(m.FileList)
+	jobs := []bootkubeRenderJob{}
+	for _, a := range append(bootkubeTemplates(), conditionalBootkubeTemplates()...) {
+		if !featureGateEnabled(a, installConfig.Config) {
+			continue
+		}
+		dependencies.Get(a)
+		for _, f := range a.Files() {
+			filename := bootkubeManifestFilename(dir, f)
+			if !tlsTemplateFields.Match(f.Data) || skipped[filepath.Base(filename)] {
+				continue
+			}
+			if _, ok := generated[filename]; conditional[a.Name()] && !ok {
+				continue
+			}
+			jobs = append(jobs, bootkubeRenderJob{
+				source:   a.Name(),
+				template: f,
+				filename: filename,
+			})
+		}
+	}
+
+	rendered, err := renderBootkubeTemplates(jobs, templateData, runtime.GOMAXPROCS(0))
+	if err != nil {
+		return err
+	}
+	for i, f := range rendered {
+		if f, err = setCriticalPriorityClass(f); err != nil {
+			return err
+		}
+		if rendered[i], err = m.annotateGenerated(f, jobs[i].source); err != nil {
+			return err
+		}
+	}
+	if m.SealedSecretsKey != nil {
+		rendered, err = sealSecrets(rendered, m.SealedSecretsKey, nil)
+		if err != nil {
+			return err
+		}
+	}
+	rendered, err = convertFiles(rendered, m.Format)
+	if err != nil {
+		return err
+	}
+	rendered, err = m.postProcessFiles(rendered, nil)
+	if err != nil {
+		return err
+	}
+
+	regenerated := make(map[string]*asset.File, len(rendered))
+	for _, f := range rendered {
+		regenerated[f.Filename] = f
+	}
+	files := make([]*asset.File, len(m.FileList))
+	checksumsIndex := -1
+	for i, f := range m.FileList {
+		if r, ok := regenerated[f.Filename]; ok {
+			f = r
+			delete(regenerated, f.Filename)
+		}
+		if isChecksumsFile(f, dir) {
+			checksumsIndex = i
+		}
+		files[i] = f
+	}
+	for filename := range regenerated {
+		return errors.Errorf("%s is not among the generated manifests", filename)
+	}
+
+	if checksumsIndex >= 0 {
+		others := append(append([]*asset.File{}, files[:checksumsIndex]...), files[checksumsIndex+1:]...)
+		checksums, err := m.checksumsFile(others)
+		if err != nil {
+			return err
+		}
+		files[checksumsIndex] = checksums
+	}
+	copy(m.FileList, files)
+	return nil
+}
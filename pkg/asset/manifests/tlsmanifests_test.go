@@ -0,0 +1,117 @@
+package manifests
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/types"
+)
+
+func TestRegenerateTLSManifests(t *testing.T) {
+	m := &Manifests{}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+	original := map[string]*asset.File{}
+	for _, f := range m.FileList {
+		original[f.Filename] = &asset.File{Filename: f.Filename, Data: append([]byte{}, f.Data...)}
+	}
+
+	// Rotate the etcd certificates.
+	signer, bundle, client := generateEtcdCerts(t)
+	parents.Add(signer, bundle, client)
+	if err := m.RegenerateTLSManifests(parents); err != nil {
+		t.Fatalf("failed to regenerate the TLS manifests: %v", err)
+	}
+
+	changed := map[string]bool{
+		"manifests/etcd-ca-bundle-configmap.yaml":  true,
+		"manifests/etcd-client-secret.yaml":        true,
+		"manifests/etcd-serving-ca-configmap.yaml": true,
+		"manifests/etcd-signer-secret.yaml":        true,
+		"manifests/manifest-checksums.yaml":        true,
+	}
+	if !assert.Len(t, m.FileList, len(original), "unexpected number of files") {
+		return
+	}
+	for _, f := range m.FileList {
+		if changed[f.Filename] {
+			assert.NotEqual(t, string(original[f.Filename].Data), string(f.Data), "%s was not regenerated", f.Filename)
+		} else {
+			assert.Equal(t, string(original[f.Filename].Data), string(f.Data), "%s unexpectedly changed", f.Filename)
+		}
+	}
+
+	// The result matches generating all of the manifests again.
+	regenerated := &Manifests{}
+	if err := regenerated.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+	assert.Equal(t, regenerated.FileList, m.FileList, "regenerated manifests differ from generated ones")
+}
+
+func TestRegenerateTLSManifestsConditional(t *testing.T) {
+	ic := testInstallConfig()
+	ic.ClusterMonitoring = true
+	ic.EtcdBackup = &types.EtcdBackup{Schedule: "0 0 * * *", Image: "quay.io/openshift/etcd-backup:latest"}
+	m := &Manifests{}
+	parents := generateTestParents(t, ic, m)
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+	backup := string(m.FileMap()["manifests/etcd-backup-client-secret.yaml"].Data)
+
+	// The conditional manifests that were generated are regenerated too.
+	signer, bundle, client := generateEtcdCerts(t)
+	parents.Add(signer, bundle, client)
+	if err := m.RegenerateTLSManifests(parents); err != nil {
+		t.Fatalf("failed to regenerate the TLS manifests: %v", err)
+	}
+	assert.NotEqual(t, backup, string(m.FileMap()["manifests/etcd-backup-client-secret.yaml"].Data), "the etcd backup client secret was not regenerated")
+
+	regenerated := &Manifests{}
+	if err := regenerated.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+	assert.Equal(t, regenerated.FileList, m.FileList, "regenerated manifests differ from generated ones")
+}
+
+func TestRegenerateTLSManifestsOptions(t *testing.T) {
+	m := &Manifests{
+		TargetKubernetesVersion: "1.16",
+		ExternalPullSecret: &ExternalPullSecretReference{
+			Name:  "clusters/test-cluster/pull-secret",
+			Store: "vault",
+		},
+	}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	if err := m.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+
+	signer, bundle, client := generateEtcdCerts(t)
+	parents.Add(signer, bundle, client)
+	if err := m.RegenerateTLSManifests(parents); err != nil {
+		t.Fatalf("failed to regenerate the TLS manifests: %v", err)
+	}
+	regenerated := &Manifests{
+		TargetKubernetesVersion: m.TargetKubernetesVersion,
+		ExternalPullSecret:      m.ExternalPullSecret,
+	}
+	if err := regenerated.Generate(parents); err != nil {
+		t.Fatalf("failed to generate manifests: %v", err)
+	}
+	assert.Equal(t, regenerated.FileList, m.FileList, "regenerated manifests differ from generated ones")
+
+	// The options are validated as they are by Generate.
+	m.ExternalPullSecret = &ExternalPullSecretReference{Store: "vault"}
+	assert.EqualError(t, m.RegenerateTLSManifests(parents), "the external pull secret name is required")
+}
+
+func TestRegenerateTLSManifestsNotGenerated(t *testing.T) {
+	m := &Manifests{}
+	assert.EqualError(t, m.RegenerateTLSManifests(asset.Parents{}), "the manifests have not been generated")
+}
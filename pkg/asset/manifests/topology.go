@@ -0,0 +1,19 @@
+package manifests
+
+const (
+	// singleReplicaTopology is the topology of control planes with a single
+	// member, which cannot tolerate the loss of any member.
+	singleReplicaTopology = "SingleReplica"
+	// highlyAvailableTopology is the topology of control planes with
+	// several members.
+	highlyAvailableTopology = "HighlyAvailable"
+)
+
+// controlPlaneTopology returns the topology of a control plane with the given
+// number of etcd members.
+func controlPlaneTopology(members int) string {
+	if members == 1 {
+		return singleReplicaTopology
+	}
+	return highlyAvailableTopology
+}
@@ -0,0 +1,112 @@
+package manifests
+
+import (
+	"path/filepath"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+const (
+	userManifestDir = "user-manifests"
+)
+
+var (
+	_ asset.WritableAsset = (*UserManifests)(nil)
+)
+
+// UserManifests is the set of additional manifests that the user placed in
+// the user-manifests directory. They are added to the generated manifests
+// unchanged.
+type UserManifests struct {
+	FileList []*asset.File
+}
+
+// Name returns a human friendly name for the asset.
+func (*UserManifests) Name() string {
+	return "User Manifests"
+}
+
+// Dependencies returns all of the dependencies directly needed to generate
+// the asset.
+func (*UserManifests) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate is a no-op. User manifests can only be provided on disk.
+func (um *UserManifests) Generate(dependencies asset.Parents) error {
+	um.FileList = []*asset.File{}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (um *UserManifests) Files() []*asset.File {
+	return um.FileList
+}
+
+// Load returns the user manifests from disk.
+func (um *UserManifests) Load(f asset.FileFetcher) (bool, error) {
+	fileList, err := f.FetchByPattern(filepath.Join(userManifestDir, "*"))
+	if err != nil {
+		return false, err
+	}
+	if len(fileList) == 0 {
+		return false, nil
+	}
+
+	for _, file := range fileList {
+		if err := validateUserManifest(file.Data); err != nil {
+			return false, errors.Wrapf(err, "failed to parse user manifest %s", file.Filename)
+		}
+	}
+
+	asset.SortFiles(fileList)
+	um.FileList = fileList
+	return true, nil
+}
+
+// validateUserManifest checks that each of the YAML documents in a user
+// manifest is an object. The file is kept as it is, so a stream of several
+// documents is written back unchanged.
+func validateUserManifest(data []byte) error {
+	index := 0
+	for _, doc := range documentSeparator.Split(string(data), -1) {
+		var obj interface{}
+		if err := yaml.Unmarshal([]byte(doc), &obj); err != nil {
+			return errors.Wrapf(err, "document %d", index)
+		}
+		// Empty and comment-only documents are ignored.
+		if obj == nil {
+			continue
+		}
+		if _, ok := obj.(map[string]interface{}); !ok {
+			return errors.Errorf("document %d is not an object", index)
+		}
+		index++
+	}
+	return nil
+}
+
+// appendUserManifests adds the user manifests to the generated files, placing
+// them in the manifests directory dir. It is an error for a user manifest to
+// have the same name as a generated manifest.
+func appendUserManifests(dir string, files []*asset.File, userFiles []*asset.File) ([]*asset.File, error) {
+	existing := make(map[string]bool, len(files))
+	for _, f := range files {
+		existing[f.Filename] = true
+	}
+	for _, uf := range userFiles {
+		filename := filepath.Join(dir, filepath.Base(uf.Filename))
+		if existing[filename] {
+			return nil, errors.Errorf("user manifest %s conflicts with generated manifest %s", uf.Filename, filename)
+		}
+		existing[filename] = true
+		files = append(files, &asset.File{
+			Filename: filename,
+			Data:     uf.Data,
+		})
+	}
+	return files, nil
+}
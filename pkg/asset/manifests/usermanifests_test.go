@@ -0,0 +1,167 @@
+package manifests
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/mock"
+)
+
+func TestUserManifestsLoad(t *testing.T) {
+	cases := []struct {
+		name          string
+		files         []*asset.File
+		expectedFound bool
+		expectedError bool
+	}{
+		{
+			name: "valid",
+			files: []*asset.File{
+				{
+					Filename: filepath.Join(userManifestDir, "namespace.yaml"),
+					Data:     []byte("apiVersion: v1\nkind: Namespace\nmetadata:\n  name: test\n"),
+				},
+			},
+			expectedFound: true,
+		},
+		{
+			name: "valid multiple documents",
+			files: []*asset.File{
+				{
+					Filename: filepath.Join(userManifestDir, "namespaces.yaml"),
+					Data:     []byte("---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: test\n---\n# comment\n---\napiVersion: v1\nkind: Namespace\nmetadata:\n  name: other\n"),
+				},
+			},
+			expectedFound: true,
+		},
+		{
+			name:          "empty",
+			expectedFound: false,
+		},
+		{
+			name: "invalid yaml",
+			files: []*asset.File{
+				{
+					Filename: filepath.Join(userManifestDir, "invalid.yaml"),
+					Data:     []byte("apiVersion: v1\nkind: [Namespace\n"),
+				},
+			},
+			expectedError: true,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			fileFetcher.EXPECT().FetchByPattern(filepath.Join(userManifestDir, "*")).Return(tc.files, nil)
+
+			um := &UserManifests{}
+			found, err := um.Load(fileFetcher)
+			assert.Equal(t, tc.expectedFound, found, "unexpected found value returned from Load")
+			if tc.expectedError {
+				assert.Error(t, err, "expected error from Load")
+			} else {
+				assert.NoError(t, err, "unexpected error from Load")
+			}
+			if tc.expectedFound {
+				assert.Equal(t, tc.files, um.FileList, "unexpected files in UserManifests")
+			}
+		})
+	}
+}
+
+func TestAppendUserManifests(t *testing.T) {
+	generated := []*asset.File{
+		{
+			Filename: kubeSysConfigPath,
+			Data:     []byte("generated"),
+		},
+	}
+	cases := []struct {
+		name          string
+		userFiles     []*asset.File
+		expectedFiles []*asset.File
+		expectedError string
+	}{
+		{
+			name: "coexisting",
+			userFiles: []*asset.File{
+				{
+					Filename: filepath.Join(userManifestDir, "quota.yaml"),
+					Data:     []byte("user"),
+				},
+			},
+			expectedFiles: []*asset.File{
+				generated[0],
+				{
+					Filename: filepath.Join(manifestDir, "quota.yaml"),
+					Data:     []byte("user"),
+				},
+			},
+		},
+		{
+			name: "colliding",
+			userFiles: []*asset.File{
+				{
+					Filename: filepath.Join(userManifestDir, filepath.Base(kubeSysConfigPath)),
+					Data:     []byte("user"),
+				},
+			},
+			expectedError: "user manifest user-manifests/cluster-config.yaml conflicts with generated manifest manifests/cluster-config.yaml",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			files, err := appendUserManifests(manifestDir, append([]*asset.File{}, generated...), tc.userFiles)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+			} else {
+				assert.NoError(t, err, "unexpected error")
+				assert.Equal(t, tc.expectedFiles, files, "unexpected files")
+			}
+		})
+	}
+}
+
+func TestUserManifestsLoadInvalidDocument(t *testing.T) {
+	cases := []struct {
+		name          string
+		data          string
+		expectedError string
+	}{
+		{
+			name:          "malformed second document",
+			data:          "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: test\n---\napiVersion: v1\nkind: [Namespace\n",
+			expectedError: `^failed to parse user manifest user-manifests/namespaces\.yaml: document 1: .*$`,
+		},
+		{
+			name:          "second document not an object",
+			data:          "apiVersion: v1\nkind: Namespace\nmetadata:\n  name: test\n---\n- item\n",
+			expectedError: `^failed to parse user manifest user-manifests/namespaces\.yaml: document 1 is not an object$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+
+			fileFetcher := mock.NewMockFileFetcher(mockCtrl)
+			fileFetcher.EXPECT().FetchByPattern(filepath.Join(userManifestDir, "*")).Return([]*asset.File{
+				{
+					Filename: filepath.Join(userManifestDir, "namespaces.yaml"),
+					Data:     []byte(tc.data),
+				},
+			}, nil)
+
+			found, err := (&UserManifests{}).Load(fileFetcher)
+			assert.False(t, found, "unexpected found value returned from Load")
+			assert.Regexp(t, tc.expectedError, err)
+		})
+	}
+}
@@ -2,8 +2,14 @@ package manifests
 
 import (
 	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/validation"
 
 	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/validate"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -15,8 +21,23 @@ type configurationObject struct {
 }
 
 type metadata struct {
-	Name      string `json:"name,omitempty"`
-	Namespace string `json:"namespace,omitempty"`
+	Name        string            `json:"name,omitempty"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+func (o *configurationObject) deepCopy() *configurationObject {
+	out := *o
+	out.Metadata.Labels = copyStringMap(o.Metadata.Labels)
+	out.Metadata.Annotations = copyStringMap(o.Metadata.Annotations)
+	if o.Data != nil {
+		out.Data = make(genericData, len(o.Data))
+		for k, v := range o.Data {
+			out.Data[k] = v
+		}
+	}
+	return &out
 }
 
 func configMap(namespace, name string, data genericData) *configurationObject {
@@ -33,6 +54,47 @@ func configMap(namespace, name string, data genericData) *configurationObject {
 	}
 }
 
+// copyStringMap returns a copy of the map, or nil if it is empty.
+func copyStringMap(in map[string]string) map[string]string {
+	if len(in) == 0 {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
+
+// sortedKeys returns the keys of the map in order.
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// validateObjectMetadata returns an error for the first label or annotation
+// that the API server would reject.
+func validateObjectMetadata(labels, annotations map[string]string) error {
+	for _, k := range sortedKeys(labels) {
+		if msgs := validation.IsQualifiedName(k); len(msgs) > 0 {
+			return errors.Errorf("invalid label key %q: %s", k, strings.Join(msgs, ", "))
+		}
+		if msgs := validation.IsValidLabelValue(labels[k]); len(msgs) > 0 {
+			return errors.Errorf("invalid value %q for label %q: %s", labels[k], k, strings.Join(msgs, ", "))
+		}
+	}
+	for _, k := range sortedKeys(annotations) {
+		if msgs := validation.IsQualifiedName(strings.ToLower(k)); len(msgs) > 0 {
+			return errors.Errorf("invalid annotation key %q: %s", k, strings.Join(msgs, ", "))
+		}
+	}
+	return nil
+}
+
 func getAPIServerURL(ic *types.InstallConfig) string {
 	return fmt.Sprintf("https://api.%s:6443", ic.ClusterDomain())
 }
@@ -41,6 +103,16 @@ func getInternalAPIServerURL(ic *types.InstallConfig) string {
 	return fmt.Sprintf("https://api-int.%s:6443", ic.ClusterDomain())
 }
 
-func getEtcdDiscoveryDomain(ic *types.InstallConfig) string {
-	return ic.ClusterDomain()
+// validateEtcdDiscoveryDomain returns an error if the etcd endpoints could
+// not be resolved under the domain because it is not a valid DNS domain.
+func validateEtcdDiscoveryDomain(domain string) error {
+	if err := validate.DomainName(domain, false); err != nil {
+		return errors.Wrapf(err, "invalid etcd discovery domain %q", domain)
+	}
+	for _, label := range strings.Split(domain, ".") {
+		if len(label) > validation.DNS1123LabelMaxLength {
+			return errors.Errorf("invalid etcd discovery domain %q: label %q is longer than %d characters", domain, label, validation.DNS1123LabelMaxLength)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,48 @@
+package manifests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// writeManifestFile writes the data to the file at path with the mode. It is
+// a variable so that the tests can observe the writes.
+var writeManifestFile = func(path string, data []byte, mode os.FileMode) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return errors.Wrap(err, "failed to create dir")
+	}
+	if err := ioutil.WriteFile(path, data, mode); err != nil {
+		return errors.Wrap(err, "failed to write file")
+	}
+	// WriteFile keeps the mode of a file that already exists.
+	return errors.Wrap(os.Chmod(path, mode), "failed to set file mode")
+}
+
+// WriteTo generates the manifests from the dependencies and writes them into
+// the directory, with the same content and modes as Generate followed by
+// asset.PersistToFile. Unlike Generate, it writes each file as soon as it is
+// rendered and does not keep it, so only one finished file is held in memory
+// at a time: the files are written in the order of FilePriority and file
+// name, with the checksums file last, and FileList is left empty.
+// KubeSysConfig is set as by Generate. If a file cannot be written, the files
+// before it are left in the directory and the files after it are not
+// rendered.
+func (m *Manifests) WriteTo(dependencies asset.Parents, dir string) error {
+	m.FileList = nil
+	kubeSysConfig, err := m.generate(dependencies, false, func(f *asset.File) error {
+		if err := writeManifestFile(filepath.Join(dir, f.Filename), f.Data, fileMode(f)); err != nil {
+			return errors.Wrapf(err, "failed to write %s", f.Filename)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	m.KubeSysConfig = kubeSysConfig
+	return nil
+}
@@ -0,0 +1,117 @@
+package manifests
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// readTree returns the contents and modes of the files under dir, by path
+// relative to dir.
+func readTree(t *testing.T, dir string) map[string]string {
+	tree := map[string]string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		tree[rel] = info.Mode().String() + "\n" + string(data)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tree
+}
+
+func TestManifestsWriteTo(t *testing.T) {
+	persisted := &Manifests{}
+	parents := generateTestParents(t, testInstallConfig(), persisted)
+	if err := persisted.Generate(parents); err != nil {
+		t.Fatal(err)
+	}
+
+	persistedDir, err := ioutil.TempDir("", "persisted")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(persistedDir)
+	writtenDir, err := ioutil.TempDir("", "written")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(writtenDir)
+
+	if err := asset.PersistToFile(persisted, persistedDir); err != nil {
+		t.Fatal(err)
+	}
+
+	// Each file must be written before the next one is rendered.
+	pending := 0
+	written := &Manifests{PostProcess: func(f *asset.File) error {
+		assert.Equal(t, 0, pending, "%s was rendered before the previous file was written", f.Filename)
+		pending++
+		return nil
+	}}
+	defer func(write func(string, []byte, os.FileMode) error) {
+		writeManifestFile = write
+	}(writeManifestFile)
+	writes := 0
+	writeFile := writeManifestFile
+	writeManifestFile = func(path string, data []byte, mode os.FileMode) error {
+		writes++
+		if pending > 0 {
+			pending--
+		}
+		return writeFile(path, data, mode)
+	}
+	if err := written.WriteTo(parents, writtenDir); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, readTree(t, persistedDir), readTree(t, writtenDir))
+	assert.Equal(t, len(persisted.FileList), writes)
+	assert.Empty(t, written.FileList, "the written manifests are held")
+	assert.Equal(t, persisted.KubeSysConfig, written.KubeSysConfig)
+}
+
+func TestManifestsWriteToFailure(t *testing.T) {
+	rendered := 0
+	m := &Manifests{PostProcess: func(f *asset.File) error {
+		rendered++
+		return nil
+	}}
+	parents := generateTestParents(t, testInstallConfig(), m)
+	defer func(write func(string, []byte, os.FileMode) error) {
+		writeManifestFile = write
+	}(writeManifestFile)
+	written := []string{}
+	writeManifestFile = func(path string, data []byte, mode os.FileMode) error {
+		if len(written) == 1 {
+			return errors.New("disk full")
+		}
+		written = append(written, path)
+		return nil
+	}
+
+	err := m.WriteTo(parents, "dir")
+	if assert.Error(t, err) {
+		assert.Regexp(t, "^failed to write .*: disk full$", err.Error())
+	}
+	assert.Len(t, written, 1)
+	assert.Equal(t, 2, rendered, "files were rendered after the failed write")
+	assert.Empty(t, m.FileList)
+}
@@ -0,0 +1,3 @@
+// Package fetchers provides the asset file fetchers that read the asset files
+// from sources other than the disk, such as archives and object storage.
+package fetchers
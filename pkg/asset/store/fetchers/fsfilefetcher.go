@@ -0,0 +1,72 @@
+//go:build go1.16
+// +build go1.16
+
+package fetchers
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+type fsFileFetcher struct {
+	fsys fs.FS
+}
+
+// NewFSFileFetcher returns a file fetcher that fetches the asset files from
+// the given file system, such as an embed.FS holding a known set of
+// manifests, instead of from disk. Each file is named by its path relative
+// to the root of the file system, which fs.Sub can move to a subdirectory.
+// Patterns are matched with the semantics of filepath.Match, and
+// directories never match. It is only built with Go 1.16 and later, which
+// added io/fs.
+func NewFSFileFetcher(fsys fs.FS) asset.FileFetcher {
+	return &fsFileFetcher{fsys: fsys}
+}
+
+// FetchByName returns the file with the given name.
+func (f *fsFileFetcher) FetchByName(name string) (*asset.File, error) {
+	data, err := fs.ReadFile(f.fsys, path.Clean(filepath.ToSlash(name)))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+		return nil, errors.Wrapf(err, "failed to read %s", name)
+	}
+	return &asset.File{Filename: name, Data: data}, nil
+}
+
+// FetchByPattern returns the files whose name match the given glob.
+func (f *fsFileFetcher) FetchByPattern(pattern string) ([]*asset.File, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	matches, err := fs.Glob(f.fsys, filepath.ToSlash(pattern))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+
+	files := make([]*asset.File, 0, len(matches))
+	for _, match := range matches {
+		info, err := fs.Stat(f.fsys, match)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to stat %s", match)
+		}
+		if info.IsDir() {
+			continue
+		}
+		file, err := f.FetchByName(filepath.FromSlash(match))
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
@@ -0,0 +1,80 @@
+//go:build go1.16
+// +build go1.16
+
+package fetchers
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func TestFSFetchByName(t *testing.T) {
+	f := NewFSFileFetcher(fstest.MapFS{
+		"foo.bar":            {Data: []byte("some data")},
+		"manifests/foo.yaml": {Data: []byte("more data")},
+	})
+
+	file, err := f.FetchByName("foo.bar")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &asset.File{Filename: "foo.bar", Data: []byte("some data")}, file)
+	}
+
+	file, err = f.FetchByName("./manifests/foo.yaml")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &asset.File{Filename: "./manifests/foo.yaml", Data: []byte("more data")}, file)
+	}
+
+	_, err = f.FetchByName("bar.foo")
+	assert.True(t, os.IsNotExist(err), "expected not-exist error, got %v", err)
+}
+
+func TestFSFetchByPattern(t *testing.T) {
+	f := NewFSFileFetcher(fstest.MapFS{
+		"master-0.ign":           {Data: []byte("some data 0")},
+		"master-1.ign":           {Data: []byte("some data 1")},
+		"master-2.ign":           {Data: []byte("some data 2")},
+		"master/master-3.ign":    {Data: []byte("some data 3")},
+		"manifests/etcd.yaml":    {Data: []byte("some data 4")},
+		"manifests/sub/foo.yaml": {Data: []byte("some data 5")},
+	})
+
+	tests := []struct {
+		input       string
+		expectFiles []*asset.File
+	}{
+		{
+			input: "master-[0-9]*.ign",
+			expectFiles: []*asset.File{
+				{Filename: "master-0.ign", Data: []byte("some data 0")},
+				{Filename: "master-1.ign", Data: []byte("some data 1")},
+				{Filename: "master-2.ign", Data: []byte("some data 2")},
+			},
+		},
+		{
+			input: "manifests/*",
+			expectFiles: []*asset.File{
+				{Filename: "manifests/etcd.yaml", Data: []byte("some data 4")},
+			},
+		},
+		{
+			input:       "openshift/*",
+			expectFiles: []*asset.File{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			files, err := f.FetchByPattern(tt.input)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expectFiles, files)
+			}
+		})
+	}
+
+	_, err := f.FetchByPattern("manifests/[")
+	assert.Error(t, err, "expected an error for an invalid pattern")
+}
@@ -0,0 +1,194 @@
+package fetchers
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+type httpFileFetcher struct {
+	baseURL *url.URL
+	client  *http.Client
+}
+
+// NewHTTPFileFetcher returns a file fetcher that fetches the asset files from
+// object storage instead of from disk. The base URL names a bucket and an
+// optional key prefix in the path-style form https://host/bucket/prefix, and
+// each file is the object named by its path under the prefix. Patterns are
+// matched against the objects listed with the S3 ListObjectsV2 API. The
+// transport, which may add authentication to the requests, defaults to
+// http.DefaultTransport.
+func NewHTTPFileFetcher(baseURL string, transport http.RoundTripper) (asset.FileFetcher, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, errors.Wrap(err, "invalid base URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, errors.Errorf("unsupported base URL scheme %q", u.Scheme)
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/")
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return &httpFileFetcher{
+		baseURL: u,
+		client:  &http.Client{Transport: transport},
+	}, nil
+}
+
+// FetchByName returns the file with the given name.
+func (f *httpFileFetcher) FetchByName(name string) (*asset.File, error) {
+	key := path.Clean(filepath.ToSlash(name))
+	u := *f.baseURL
+	u.Path = u.Path + "/" + key
+	data, err := f.get(&u)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+		}
+		return nil, err
+	}
+	return &asset.File{Filename: name, Data: data}, nil
+}
+
+// FetchByPattern returns the files whose name match the given glob.
+func (f *httpFileFetcher) FetchByPattern(pattern string) ([]*asset.File, error) {
+	if _, err := filepath.Match(pattern, ""); err != nil {
+		return nil, err
+	}
+	keys, err := f.list(globPrefix(filepath.ToSlash(pattern)))
+	if err != nil {
+		return nil, err
+	}
+
+	names := []string{}
+	for _, key := range keys {
+		name := filepath.FromSlash(key)
+		matched, err := filepath.Match(pattern, name)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	files := make([]*asset.File, 0, len(names))
+	for _, name := range names {
+		file, err := f.FetchByName(name)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, file)
+	}
+	return files, nil
+}
+
+// listBucketResult is the response to a ListObjectsV2 request.
+type listBucketResult struct {
+	Contents []struct {
+		Key string
+	}
+	IsTruncated           bool
+	NextContinuationToken string
+}
+
+// list returns the keys of the objects under the base URL that start with
+// the prefix, relative to the base URL.
+func (f *httpFileFetcher) list(prefix string) ([]string, error) {
+	base := strings.TrimPrefix(f.baseURL.Path, "/")
+	bucket, keyPrefix := base, ""
+	if i := strings.Index(base, "/"); i >= 0 {
+		bucket, keyPrefix = base[:i], base[i+1:]+"/"
+	}
+
+	keys := []string{}
+	token := ""
+	for {
+		u := *f.baseURL
+		u.Path = "/" + bucket
+		query := url.Values{
+			"list-type": {"2"},
+			"prefix":    {keyPrefix + prefix},
+		}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		u.RawQuery = query.Encode()
+
+		data, err := f.get(&u)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to list %s", prefix)
+		}
+		var result listBucketResult
+		if err := xml.Unmarshal(data, &result); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse the listing of %s", prefix)
+		}
+		for _, c := range result.Contents {
+			keys = append(keys, strings.TrimPrefix(c.Key, keyPrefix))
+		}
+		if !result.IsTruncated || result.NextContinuationToken == "" {
+			return keys, nil
+		}
+		token = result.NextContinuationToken
+	}
+}
+
+// httpStatusError is returned for responses other than 200 OK.
+type httpStatusError struct {
+	url    string
+	status int
+}
+
+func (e *httpStatusError) Error() string {
+	return fmt.Sprintf("GET %s: %d %s", e.url, e.status, http.StatusText(e.status))
+}
+
+func isNotFound(err error) bool {
+	statusErr, ok := err.(*httpStatusError)
+	return ok && statusErr.status == http.StatusNotFound
+}
+
+func (f *httpFileFetcher) get(u *url.URL) ([]byte, error) {
+	resp, err := f.client.Get(u.String())
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpStatusError{url: redactedURL(u), status: resp.StatusCode}
+	}
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read %s", redactedURL(u))
+	}
+	return data, nil
+}
+
+// redactedURL returns the URL without its user information.
+func redactedURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	return redacted.String()
+}
+
+// globPrefix returns the part of the slash-separated pattern before its
+// first wildcard.
+func globPrefix(pattern string) string {
+	if i := strings.IndexAny(pattern, `*?[\`); i >= 0 {
+		return pattern[:i]
+	}
+	return pattern
+}
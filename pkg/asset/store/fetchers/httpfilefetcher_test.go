@@ -0,0 +1,151 @@
+package fetchers
+
+import (
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+// bucketServer serves the objects of a single bucket, listing at most
+// pageSize of them per ListObjectsV2 response.
+func bucketServer(t *testing.T, bucket string, objects map[string]string, pageSize int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/"+bucket && r.URL.Query().Get("list-type") == "2" {
+			keys := []string{}
+			for key := range objects {
+				if strings.HasPrefix(key, r.URL.Query().Get("prefix")) {
+					keys = append(keys, key)
+				}
+			}
+			sort.Strings(keys)
+			type content struct {
+				Key string
+			}
+			result := struct {
+				XMLName               xml.Name `xml:"ListBucketResult"`
+				Contents              []content
+				IsTruncated           bool
+				NextContinuationToken string `xml:",omitempty"`
+			}{}
+			for _, key := range keys {
+				if token := r.URL.Query().Get("continuation-token"); token != "" && key < token {
+					continue
+				}
+				if len(result.Contents) == pageSize {
+					result.IsTruncated = true
+					result.NextContinuationToken = key
+					break
+				}
+				result.Contents = append(result.Contents, content{Key: key})
+			}
+			if err := xml.NewEncoder(w).Encode(result); err != nil {
+				t.Error(err)
+			}
+			return
+		}
+		data, ok := objects[strings.TrimPrefix(r.URL.Path, "/"+bucket+"/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(data))
+	}))
+}
+
+func TestHTTPFetchByName(t *testing.T) {
+	server := bucketServer(t, "bucket", map[string]string{
+		"cluster/manifests/foo.yaml": "some data",
+	}, 1000)
+	defer server.Close()
+
+	f, err := NewHTTPFileFetcher(server.URL+"/bucket/cluster/", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	file, err := f.FetchByName("manifests/foo.yaml")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &asset.File{Filename: "manifests/foo.yaml", Data: []byte("some data")}, file)
+	}
+
+	_, err = f.FetchByName("manifests/missing.yaml")
+	assert.True(t, os.IsNotExist(err), "unexpected error for a missing file: %v", err)
+}
+
+func TestHTTPFetchByPattern(t *testing.T) {
+	objects := map[string]string{
+		"cluster/manifests/b.yaml":        "b",
+		"cluster/manifests/a.yaml":        "a",
+		"cluster/manifests/nested/c.yaml": "c",
+		"cluster/openshift/d.yaml":        "d",
+		"other/manifests/e.yaml":          "e",
+	}
+	for _, pageSize := range []int{1, 1000} {
+		server := bucketServer(t, "bucket", objects, pageSize)
+		defer server.Close()
+
+		f, err := NewHTTPFileFetcher(server.URL+"/bucket/cluster", nil)
+		if !assert.NoError(t, err) {
+			return
+		}
+		files, err := f.FetchByPattern("manifests/*")
+		if assert.NoError(t, err, "unexpected error with pages of %d", pageSize) {
+			assert.Equal(t, []*asset.File{
+				{Filename: "manifests/a.yaml", Data: []byte("a")},
+				{Filename: "manifests/b.yaml", Data: []byte("b")},
+			}, files, "unexpected files with pages of %d", pageSize)
+		}
+	}
+}
+
+type headerTransport struct {
+	header, value string
+}
+
+func (t *headerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.Header.Set(t.header, t.value)
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+func TestHTTPFileFetcherTransport(t *testing.T) {
+	objects := bucketServer(t, "bucket", map[string]string{"foo.yaml": "some data"}, 1000)
+	defer objects.Close()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		objects.Config.Handler.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	f, err := NewHTTPFileFetcher(server.URL+"/bucket", nil)
+	if !assert.NoError(t, err) {
+		return
+	}
+	_, err = f.FetchByName("foo.yaml")
+	assert.EqualError(t, err, "GET "+server.URL+"/bucket/foo.yaml: 401 Unauthorized")
+
+	f, err = NewHTTPFileFetcher(server.URL+"/bucket", &headerTransport{header: "Authorization", value: "Bearer token"})
+	if !assert.NoError(t, err) {
+		return
+	}
+	file, err := f.FetchByName("foo.yaml")
+	if assert.NoError(t, err) {
+		assert.Equal(t, []byte("some data"), file.Data)
+	}
+}
+
+func TestNewHTTPFileFetcherInvalidURL(t *testing.T) {
+	_, err := NewHTTPFileFetcher("file:///tmp/manifests", nil)
+	assert.EqualError(t, err, `unsupported base URL scheme "file"`)
+}
@@ -0,0 +1,85 @@
+package fetchers
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+type tarFileFetcher struct {
+	files map[string][]byte
+}
+
+// NewTarGzFileFetcher returns a file fetcher that fetches the asset files from
+// the given gzipped tar archive instead of from disk. The whole archive is
+// read into memory.
+func NewTarGzFileFetcher(r io.Reader) (asset.FileFetcher, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create gzip reader")
+	}
+	defer gr.Close()
+
+	f := &tarFileFetcher{files: map[string][]byte{}}
+	tr := tar.NewReader(gr)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read tar header")
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to read %s", header.Name)
+		}
+		f.files[filepath.FromSlash(path.Clean(header.Name))] = data
+	}
+	return f, nil
+}
+
+// FetchByName returns the file with the given name.
+func (f *tarFileFetcher) FetchByName(name string) (*asset.File, error) {
+	data, ok := f.files[filepath.Clean(name)]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return &asset.File{Filename: name, Data: data}, nil
+}
+
+// FetchByPattern returns the files whose name match the given glob.
+func (f *tarFileFetcher) FetchByPattern(pattern string) ([]*asset.File, error) {
+	names := []string{}
+	for name := range f.files {
+		matched, err := filepath.Match(pattern, name)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	files := make([]*asset.File, 0, len(names))
+	for _, name := range names {
+		files = append(files, &asset.File{
+			Filename: name,
+			Data:     f.files[name],
+		})
+	}
+	return files, nil
+}
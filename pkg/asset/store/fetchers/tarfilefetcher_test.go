@@ -0,0 +1,97 @@
+package fetchers
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
+)
+
+func tarGz(t *testing.T, files map[string][]byte) []byte {
+	buf := &bytes.Buffer{}
+	gw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gw)
+	for name, data := range files {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(data)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestTarGzFetchByName(t *testing.T) {
+	f, err := NewTarGzFileFetcher(bytes.NewReader(tarGz(t, map[string][]byte{
+		"./foo.bar": []byte("some data"),
+	})))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	file, err := f.FetchByName("foo.bar")
+	if assert.NoError(t, err) {
+		assert.Equal(t, &asset.File{Filename: "foo.bar", Data: []byte("some data")}, file)
+	}
+
+	_, err = f.FetchByName("bar.foo")
+	assert.True(t, os.IsNotExist(err), "expected not-exist error, got %v", err)
+}
+
+func TestTarGzFetchByPattern(t *testing.T) {
+	f, err := NewTarGzFileFetcher(bytes.NewReader(tarGz(t, map[string][]byte{
+		"master-0.ign":           []byte("some data 0"),
+		"master-1.ign":           []byte("some data 1"),
+		"master-2.ign":           []byte("some data 2"),
+		"master/master-3.ign":    []byte("some data 3"),
+		"manifests/etcd.yaml":    []byte("some data 4"),
+		"manifests/sub/foo.yaml": []byte("some data 5"),
+	})))
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	tests := []struct {
+		input       string
+		expectFiles []*asset.File
+	}{
+		{
+			input: "master-[0-9]*.ign",
+			expectFiles: []*asset.File{
+				{Filename: "master-0.ign", Data: []byte("some data 0")},
+				{Filename: "master-1.ign", Data: []byte("some data 1")},
+				{Filename: "master-2.ign", Data: []byte("some data 2")},
+			},
+		},
+		{
+			input: "manifests/*",
+			expectFiles: []*asset.File{
+				{Filename: "manifests/etcd.yaml", Data: []byte("some data 4")},
+			},
+		},
+		{
+			input:       "openshift/*",
+			expectFiles: []*asset.File{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			files, err := f.FetchByPattern(tt.input)
+			if assert.NoError(t, err) {
+				assert.Equal(t, tt.expectFiles, files)
+			}
+		})
+	}
+}
@@ -33,6 +33,8 @@ var (
 	ManifestTemplates = []asset.WritableAsset{
 		&bootkube.KubeCloudConfig{},
 		&bootkube.MachineConfigServerTLSSecret{},
+		&bootkube.MachineConfigServerTLSSecretReaderRole{},
+		&bootkube.MachineConfigServerTLSSecretReaderRoleBinding{},
 		&bootkube.CVOOverrides{},
 		&bootkube.EtcdHostServiceEndpoints{},
 		&bootkube.EtcdServingCAConfigMap{},
@@ -42,6 +44,16 @@ var (
 		&bootkube.EtcdNamespace{},
 		&bootkube.EtcdService{},
 		&bootkube.EtcdHostService{},
+		&bootkube.EtcdPodDisruptionBudget{},
+		&bootkube.EtcdBackupClientSecret{},
+		&bootkube.EtcdBackupCronJob{},
+		&bootkube.EtcdBackupServiceAccount{},
+		&bootkube.EtcdBackupRole{},
+		&bootkube.EtcdBackupRoleBinding{},
+		&bootkube.AlertmanagerMainSecret{},
+		&bootkube.EtcdServiceMonitor{},
+		&bootkube.EtcdMonitoringClientSecret{},
+		&bootkube.EtcdMonitoringServingCAConfigMap{},
 		&bootkube.EtcdMetricClientSecret{},
 		&bootkube.EtcdMetricSignerSecret{},
 		&bootkube.EtcdMetricServingCAConfigMap{},
@@ -0,0 +1,64 @@
+package bootkube
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content"
+)
+
+const (
+	alertmanagerMainSecretFileName = "alertmanager-main-secret.yaml.template"
+)
+
+var _ asset.WritableAsset = (*AlertmanagerMainSecret)(nil)
+
+// AlertmanagerMainSecret is an asset for the configuration of the alert receiver
+type AlertmanagerMainSecret struct {
+	FileList []*asset.File
+}
+
+// Dependencies returns all of the dependencies directly needed by the asset
+func (t *AlertmanagerMainSecret) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name returns the human-friendly name of the asset.
+func (t *AlertmanagerMainSecret) Name() string {
+	return "AlertmanagerMainSecret"
+}
+
+// Generate generates the actual files by this asset
+func (t *AlertmanagerMainSecret) Generate(parents asset.Parents) error {
+	fileName := alertmanagerMainSecretFileName
+	data, err := content.GetBootkubeTemplate(fileName)
+	if err != nil {
+		return err
+	}
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join(content.TemplateDir, fileName),
+			Data:     []byte(data),
+		},
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (t *AlertmanagerMainSecret) Files() []*asset.File {
+	return t.FileList
+}
+
+// Load returns the asset from disk.
+func (t *AlertmanagerMainSecret) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(filepath.Join(content.TemplateDir, alertmanagerMainSecretFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	t.FileList = []*asset.File{file}
+	return true, nil
+}
@@ -0,0 +1,64 @@
+package bootkube
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content"
+)
+
+const (
+	etcdBackupClientSecretFileName = "etcd-backup-client-secret.yaml.template"
+)
+
+var _ asset.WritableAsset = (*EtcdBackupClientSecret)(nil)
+
+// EtcdBackupClientSecret is an asset for the etcd client certificate of the etcd backups
+type EtcdBackupClientSecret struct {
+	FileList []*asset.File
+}
+
+// Dependencies returns all of the dependencies directly needed by the asset
+func (t *EtcdBackupClientSecret) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name returns the human-friendly name of the asset.
+func (t *EtcdBackupClientSecret) Name() string {
+	return "EtcdBackupClientSecret"
+}
+
+// Generate generates the actual files by this asset
+func (t *EtcdBackupClientSecret) Generate(parents asset.Parents) error {
+	fileName := etcdBackupClientSecretFileName
+	data, err := content.GetBootkubeTemplate(fileName)
+	if err != nil {
+		return err
+	}
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join(content.TemplateDir, fileName),
+			Data:     []byte(data),
+		},
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (t *EtcdBackupClientSecret) Files() []*asset.File {
+	return t.FileList
+}
+
+// Load returns the asset from disk.
+func (t *EtcdBackupClientSecret) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(filepath.Join(content.TemplateDir, etcdBackupClientSecretFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	t.FileList = []*asset.File{file}
+	return true, nil
+}
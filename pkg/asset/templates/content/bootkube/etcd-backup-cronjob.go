@@ -0,0 +1,64 @@
+package bootkube
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content"
+)
+
+const (
+	etcdBackupCronJobFileName = "etcd-backup-cronjob.yaml.template"
+)
+
+var _ asset.WritableAsset = (*EtcdBackupCronJob)(nil)
+
+// EtcdBackupCronJob is an asset for the CronJob periodically snapshotting etcd
+type EtcdBackupCronJob struct {
+	FileList []*asset.File
+}
+
+// Dependencies returns all of the dependencies directly needed by the asset
+func (t *EtcdBackupCronJob) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name returns the human-friendly name of the asset.
+func (t *EtcdBackupCronJob) Name() string {
+	return "EtcdBackupCronJob"
+}
+
+// Generate generates the actual files by this asset
+func (t *EtcdBackupCronJob) Generate(parents asset.Parents) error {
+	fileName := etcdBackupCronJobFileName
+	data, err := content.GetBootkubeTemplate(fileName)
+	if err != nil {
+		return err
+	}
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join(content.TemplateDir, fileName),
+			Data:     []byte(data),
+		},
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (t *EtcdBackupCronJob) Files() []*asset.File {
+	return t.FileList
+}
+
+// Load returns the asset from disk.
+func (t *EtcdBackupCronJob) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(filepath.Join(content.TemplateDir, etcdBackupCronJobFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	t.FileList = []*asset.File{file}
+	return true, nil
+}
@@ -0,0 +1,64 @@
+package bootkube
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content"
+)
+
+const (
+	etcdBackupRoleFileName = "etcd-backup-role.yaml"
+)
+
+var _ asset.WritableAsset = (*EtcdBackupRole)(nil)
+
+// EtcdBackupRole is an asset for the Role letting the etcd backup CronJob use the hostmount-anyuid SCC
+type EtcdBackupRole struct {
+	FileList []*asset.File
+}
+
+// Dependencies returns all of the dependencies directly needed by the asset
+func (t *EtcdBackupRole) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name returns the human-friendly name of the asset.
+func (t *EtcdBackupRole) Name() string {
+	return "EtcdBackupRole"
+}
+
+// Generate generates the actual files by this asset
+func (t *EtcdBackupRole) Generate(parents asset.Parents) error {
+	fileName := etcdBackupRoleFileName
+	data, err := content.GetBootkubeTemplate(fileName)
+	if err != nil {
+		return err
+	}
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join(content.TemplateDir, fileName),
+			Data:     []byte(data),
+		},
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (t *EtcdBackupRole) Files() []*asset.File {
+	return t.FileList
+}
+
+// Load returns the asset from disk.
+func (t *EtcdBackupRole) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(filepath.Join(content.TemplateDir, etcdBackupRoleFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	t.FileList = []*asset.File{file}
+	return true, nil
+}
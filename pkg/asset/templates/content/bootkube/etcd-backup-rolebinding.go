@@ -0,0 +1,64 @@
+package bootkube
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content"
+)
+
+const (
+	etcdBackupRoleBindingFileName = "etcd-backup-rolebinding.yaml"
+)
+
+var _ asset.WritableAsset = (*EtcdBackupRoleBinding)(nil)
+
+// EtcdBackupRoleBinding is an asset for the RoleBinding granting the etcd backup Role to its ServiceAccount
+type EtcdBackupRoleBinding struct {
+	FileList []*asset.File
+}
+
+// Dependencies returns all of the dependencies directly needed by the asset
+func (t *EtcdBackupRoleBinding) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name returns the human-friendly name of the asset.
+func (t *EtcdBackupRoleBinding) Name() string {
+	return "EtcdBackupRoleBinding"
+}
+
+// Generate generates the actual files by this asset
+func (t *EtcdBackupRoleBinding) Generate(parents asset.Parents) error {
+	fileName := etcdBackupRoleBindingFileName
+	data, err := content.GetBootkubeTemplate(fileName)
+	if err != nil {
+		return err
+	}
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join(content.TemplateDir, fileName),
+			Data:     []byte(data),
+		},
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (t *EtcdBackupRoleBinding) Files() []*asset.File {
+	return t.FileList
+}
+
+// Load returns the asset from disk.
+func (t *EtcdBackupRoleBinding) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(filepath.Join(content.TemplateDir, etcdBackupRoleBindingFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	t.FileList = []*asset.File{file}
+	return true, nil
+}
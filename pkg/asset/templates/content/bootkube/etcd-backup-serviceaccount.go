@@ -0,0 +1,64 @@
+package bootkube
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content"
+)
+
+const (
+	etcdBackupServiceAccountFileName = "etcd-backup-serviceaccount.yaml"
+)
+
+var _ asset.WritableAsset = (*EtcdBackupServiceAccount)(nil)
+
+// EtcdBackupServiceAccount is an asset for the ServiceAccount the etcd backup CronJob runs as
+type EtcdBackupServiceAccount struct {
+	FileList []*asset.File
+}
+
+// Dependencies returns all of the dependencies directly needed by the asset
+func (t *EtcdBackupServiceAccount) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name returns the human-friendly name of the asset.
+func (t *EtcdBackupServiceAccount) Name() string {
+	return "EtcdBackupServiceAccount"
+}
+
+// Generate generates the actual files by this asset
+func (t *EtcdBackupServiceAccount) Generate(parents asset.Parents) error {
+	fileName := etcdBackupServiceAccountFileName
+	data, err := content.GetBootkubeTemplate(fileName)
+	if err != nil {
+		return err
+	}
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join(content.TemplateDir, fileName),
+			Data:     []byte(data),
+		},
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (t *EtcdBackupServiceAccount) Files() []*asset.File {
+	return t.FileList
+}
+
+// Load returns the asset from disk.
+func (t *EtcdBackupServiceAccount) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(filepath.Join(content.TemplateDir, etcdBackupServiceAccountFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	t.FileList = []*asset.File{file}
+	return true, nil
+}
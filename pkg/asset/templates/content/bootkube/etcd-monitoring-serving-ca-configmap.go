@@ -0,0 +1,64 @@
+package bootkube
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content"
+)
+
+const (
+	etcdMonitoringServingCAConfigMapFileName = "etcd-monitoring-serving-ca-configmap.yaml.template"
+)
+
+var _ asset.WritableAsset = (*EtcdMonitoringServingCAConfigMap)(nil)
+
+// EtcdMonitoringServingCAConfigMap is an asset for the etcd metric serving CA of the ServiceMonitor
+type EtcdMonitoringServingCAConfigMap struct {
+	FileList []*asset.File
+}
+
+// Dependencies returns all of the dependencies directly needed by the asset
+func (t *EtcdMonitoringServingCAConfigMap) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name returns the human-friendly name of the asset.
+func (t *EtcdMonitoringServingCAConfigMap) Name() string {
+	return "EtcdMonitoringServingCAConfigMap"
+}
+
+// Generate generates the actual files by this asset
+func (t *EtcdMonitoringServingCAConfigMap) Generate(parents asset.Parents) error {
+	fileName := etcdMonitoringServingCAConfigMapFileName
+	data, err := content.GetBootkubeTemplate(fileName)
+	if err != nil {
+		return err
+	}
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join(content.TemplateDir, fileName),
+			Data:     []byte(data),
+		},
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (t *EtcdMonitoringServingCAConfigMap) Files() []*asset.File {
+	return t.FileList
+}
+
+// Load returns the asset from disk.
+func (t *EtcdMonitoringServingCAConfigMap) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(filepath.Join(content.TemplateDir, etcdMonitoringServingCAConfigMapFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	t.FileList = []*asset.File{file}
+	return true, nil
+}
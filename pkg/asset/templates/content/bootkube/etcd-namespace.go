@@ -9,7 +9,7 @@ import (
 )
 
 const (
-	etcdNamespaceFileName = "etcd-namespace.yaml"
+	etcdNamespaceFileName = "etcd-namespace.yaml.template"
 )
 
 var _ asset.WritableAsset = (*EtcdNamespace)(nil)
@@ -0,0 +1,64 @@
+package bootkube
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content"
+)
+
+const (
+	etcdPodDisruptionBudgetFileName = "etcd-pod-disruption-budget.yaml.template"
+)
+
+var _ asset.WritableAsset = (*EtcdPodDisruptionBudget)(nil)
+
+// EtcdPodDisruptionBudget is an asset for the PodDisruptionBudget protecting the etcd quorum
+type EtcdPodDisruptionBudget struct {
+	FileList []*asset.File
+}
+
+// Dependencies returns all of the dependencies directly needed by the asset
+func (t *EtcdPodDisruptionBudget) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name returns the human-friendly name of the asset.
+func (t *EtcdPodDisruptionBudget) Name() string {
+	return "EtcdPodDisruptionBudget"
+}
+
+// Generate generates the actual files by this asset
+func (t *EtcdPodDisruptionBudget) Generate(parents asset.Parents) error {
+	fileName := etcdPodDisruptionBudgetFileName
+	data, err := content.GetBootkubeTemplate(fileName)
+	if err != nil {
+		return err
+	}
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join(content.TemplateDir, fileName),
+			Data:     []byte(data),
+		},
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (t *EtcdPodDisruptionBudget) Files() []*asset.File {
+	return t.FileList
+}
+
+// Load returns the asset from disk.
+func (t *EtcdPodDisruptionBudget) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(filepath.Join(content.TemplateDir, etcdPodDisruptionBudgetFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	t.FileList = []*asset.File{file}
+	return true, nil
+}
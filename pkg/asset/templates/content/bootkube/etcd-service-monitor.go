@@ -0,0 +1,64 @@
+package bootkube
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content"
+)
+
+const (
+	etcdServiceMonitorFileName = "etcd-service-monitor.yaml"
+)
+
+var _ asset.WritableAsset = (*EtcdServiceMonitor)(nil)
+
+// EtcdServiceMonitor is an asset for the ServiceMonitor scraping the etcd metrics
+type EtcdServiceMonitor struct {
+	FileList []*asset.File
+}
+
+// Dependencies returns all of the dependencies directly needed by the asset
+func (t *EtcdServiceMonitor) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name returns the human-friendly name of the asset.
+func (t *EtcdServiceMonitor) Name() string {
+	return "EtcdServiceMonitor"
+}
+
+// Generate generates the actual files by this asset
+func (t *EtcdServiceMonitor) Generate(parents asset.Parents) error {
+	fileName := etcdServiceMonitorFileName
+	data, err := content.GetBootkubeTemplate(fileName)
+	if err != nil {
+		return err
+	}
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join(content.TemplateDir, fileName),
+			Data:     []byte(data),
+		},
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (t *EtcdServiceMonitor) Files() []*asset.File {
+	return t.FileList
+}
+
+// Load returns the asset from disk.
+func (t *EtcdServiceMonitor) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(filepath.Join(content.TemplateDir, etcdServiceMonitorFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	t.FileList = []*asset.File{file}
+	return true, nil
+}
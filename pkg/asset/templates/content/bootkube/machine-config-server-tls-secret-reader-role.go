@@ -0,0 +1,64 @@
+package bootkube
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content"
+)
+
+const (
+	machineConfigServerTLSSecretReaderRoleFileName = "machine-config-server-tls-secret-reader-role.yaml"
+)
+
+var _ asset.WritableAsset = (*MachineConfigServerTLSSecretReaderRole)(nil)
+
+// MachineConfigServerTLSSecretReaderRole is an asset for the Role allowing reads of the MCS TLS secret
+type MachineConfigServerTLSSecretReaderRole struct {
+	FileList []*asset.File
+}
+
+// Dependencies returns all of the dependencies directly needed by the asset
+func (t *MachineConfigServerTLSSecretReaderRole) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name returns the human-friendly name of the asset.
+func (t *MachineConfigServerTLSSecretReaderRole) Name() string {
+	return "MachineConfigServerTLSSecretReaderRole"
+}
+
+// Generate generates the actual files by this asset
+func (t *MachineConfigServerTLSSecretReaderRole) Generate(parents asset.Parents) error {
+	fileName := machineConfigServerTLSSecretReaderRoleFileName
+	data, err := content.GetBootkubeTemplate(fileName)
+	if err != nil {
+		return err
+	}
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join(content.TemplateDir, fileName),
+			Data:     []byte(data),
+		},
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (t *MachineConfigServerTLSSecretReaderRole) Files() []*asset.File {
+	return t.FileList
+}
+
+// Load returns the asset from disk.
+func (t *MachineConfigServerTLSSecretReaderRole) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(filepath.Join(content.TemplateDir, machineConfigServerTLSSecretReaderRoleFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	t.FileList = []*asset.File{file}
+	return true, nil
+}
@@ -0,0 +1,64 @@
+package bootkube
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/templates/content"
+)
+
+const (
+	machineConfigServerTLSSecretReaderRoleBindingFileName = "machine-config-server-tls-secret-reader-rolebinding.yaml"
+)
+
+var _ asset.WritableAsset = (*MachineConfigServerTLSSecretReaderRoleBinding)(nil)
+
+// MachineConfigServerTLSSecretReaderRoleBinding is an asset for the RoleBinding granting the MCO read access to the MCS TLS secret
+type MachineConfigServerTLSSecretReaderRoleBinding struct {
+	FileList []*asset.File
+}
+
+// Dependencies returns all of the dependencies directly needed by the asset
+func (t *MachineConfigServerTLSSecretReaderRoleBinding) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Name returns the human-friendly name of the asset.
+func (t *MachineConfigServerTLSSecretReaderRoleBinding) Name() string {
+	return "MachineConfigServerTLSSecretReaderRoleBinding"
+}
+
+// Generate generates the actual files by this asset
+func (t *MachineConfigServerTLSSecretReaderRoleBinding) Generate(parents asset.Parents) error {
+	fileName := machineConfigServerTLSSecretReaderRoleBindingFileName
+	data, err := content.GetBootkubeTemplate(fileName)
+	if err != nil {
+		return err
+	}
+	t.FileList = []*asset.File{
+		{
+			Filename: filepath.Join(content.TemplateDir, fileName),
+			Data:     []byte(data),
+		},
+	}
+	return nil
+}
+
+// Files returns the files generated by the asset.
+func (t *MachineConfigServerTLSSecretReaderRoleBinding) Files() []*asset.File {
+	return t.FileList
+}
+
+// Load returns the asset from disk.
+func (t *MachineConfigServerTLSSecretReaderRoleBinding) Load(f asset.FileFetcher) (bool, error) {
+	file, err := f.FetchByName(filepath.Join(content.TemplateDir, machineConfigServerTLSSecretReaderRoleBindingFileName))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	t.FileList = []*asset.File{file}
+	return true, nil
+}
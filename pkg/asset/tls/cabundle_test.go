@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -140,3 +142,36 @@ CjH8bTNT3u6KYZKRVH0A2/EpJHC+TSSe3A==
 		})
 	}
 }
+
+func TestEtcdCABundleExternalEtcd(t *testing.T) {
+	signer := &EtcdSignerCertKey{}
+	if err := signer.Generate(nil); err != nil {
+		t.Fatal(err)
+	}
+	externalCAs := append(generateTestCA(t, "external-etcd-ca-1").Cert(), generateTestCA(t, "external-etcd-ca-2").Cert()...)
+
+	for _, tc := range []struct {
+		name         string
+		externalEtcd *types.ExternalEtcd
+		expected     []byte
+	}{
+		{
+			name:     "in-cluster",
+			expected: signer.Cert(),
+		},
+		{
+			name:         "external",
+			externalEtcd: &types.ExternalEtcd{CABundle: string(externalCAs)},
+			expected:     append(append([]byte{}, signer.Cert()...), externalCAs...),
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			parents := asset.Parents{}
+			parents.Add(signer, &installconfig.InstallConfig{Config: &types.InstallConfig{ExternalEtcd: tc.externalEtcd}})
+			bundle := &EtcdCABundle{}
+			if assert.NoError(t, bundle.Generate(parents)) {
+				assert.Equal(t, string(tc.expected), string(bundle.Cert()))
+			}
+		})
+	}
+}
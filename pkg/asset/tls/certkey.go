@@ -2,8 +2,9 @@ package tls
 
 import (
 	"bytes"
-	"crypto/rsa"
+	"crypto"
 	"crypto/x509"
+	"os"
 
 	"github.com/pkg/errors"
 
@@ -68,6 +69,56 @@ func (c *CertKey) Load(asset.FileFetcher) (bool, error) {
 	return false, nil
 }
 
+// loadCA loads the CA key/cert pair that was provided in the target directory
+// under the filename base. It returns false when neither file is there. The
+// certificate must be a CA and the key must match it.
+func (c *CertKey) loadCA(f asset.FileFetcher, filenameBase string) (bool, error) {
+	keyFile, err := f.FetchByName(assetFilePath(filenameBase + ".key"))
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	certFile, err := f.FetchByName(assetFilePath(filenameBase + ".crt"))
+	if err != nil && !os.IsNotExist(err) {
+		return false, err
+	}
+	switch {
+	case keyFile == nil && certFile == nil:
+		return false, nil
+	case keyFile == nil:
+		return false, errors.Errorf("%s was provided without %s", certFile.Filename, assetFilePath(filenameBase+".key"))
+	case certFile == nil:
+		return false, errors.Errorf("%s was provided without %s", keyFile.Filename, assetFilePath(filenameBase+".crt"))
+	}
+
+	cert, err := PemToCertificate(certFile.Data)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse %s", certFile.Filename)
+	}
+	if !cert.IsCA {
+		return false, errors.Errorf("%s is not a CA certificate", certFile.Filename)
+	}
+	key, err := PemToPrivateKey(keyFile.Data)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse %s", keyFile.Filename)
+	}
+	certPublicKey, err := x509.MarshalPKIXPublicKey(cert.PublicKey)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to marshal the public key of %s", certFile.Filename)
+	}
+	keyPublicKey, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to marshal the public key of %s", keyFile.Filename)
+	}
+	if !bytes.Equal(certPublicKey, keyPublicKey) {
+		return false, errors.Errorf("%s does not match %s", keyFile.Filename, certFile.Filename)
+	}
+
+	c.KeyRaw = keyFile.Data
+	c.CertRaw = certFile.Data
+	c.FileList = []*asset.File{keyFile, certFile}
+	return true, nil
+}
+
 // AppendParentChoice dictates whether the parent's cert is to be added to the
 // cert.
 type AppendParentChoice bool
@@ -92,13 +143,13 @@ func (c *SignedCertKey) Generate(
 	filenameBase string,
 	appendParent AppendParentChoice,
 ) error {
-	var key *rsa.PrivateKey
+	var key crypto.Signer
 	var crt *x509.Certificate
 	var err error
 
 	caKey, err := PemToPrivateKey(parentCA.Key())
 	if err != nil {
-		return errors.Wrap(err, "failed to parse rsa private key")
+		return errors.Wrap(err, "failed to parse private key")
 	}
 
 	caCert, err := PemToCertificate(parentCA.Cert())
@@ -111,7 +162,10 @@ func (c *SignedCertKey) Generate(
 		return errors.Wrap(err, "failed to generate signed cert/key pair")
 	}
 
-	c.KeyRaw = PrivateKeyToPem(key)
+	c.KeyRaw, err = PrivateKeyToPem(key)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode private key")
+	}
 	c.CertRaw = CertToPem(crt)
 
 	if appendParent {
@@ -138,7 +192,10 @@ func (c *SelfSignedCertKey) Generate(
 		return errors.Wrap(err, "failed to generate self-signed cert/key pair")
 	}
 
-	c.KeyRaw = PrivateKeyToPem(key)
+	c.KeyRaw, err = PrivateKeyToPem(key)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode private key")
+	}
 	c.CertRaw = CertToPem(crt)
 
 	c.generateFiles(filenameBase)
@@ -4,9 +4,13 @@ import (
 	"crypto/x509"
 	"crypto/x509/pkix"
 	"net"
+	"os"
 	"testing"
 
+	"github.com/pkg/errors"
 	"github.com/stretchr/testify/assert"
+
+	"github.com/openshift/installer/pkg/asset"
 )
 
 func TestSignedCertKeyGenerate(t *testing.T) {
@@ -88,3 +92,113 @@ func TestSignedCertKeyGenerate(t *testing.T) {
 		})
 	}
 }
+
+// fileFetcher fetches the files from a map of file names to contents.
+type fileFetcher map[string][]byte
+
+func (f fileFetcher) FetchByName(name string) (*asset.File, error) {
+	data, ok := f[name]
+	if !ok {
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return &asset.File{Filename: name, Data: data}, nil
+}
+
+func (f fileFetcher) FetchByPattern(pattern string) ([]*asset.File, error) {
+	return nil, errors.New("not implemented")
+}
+
+func generateTestCA(t *testing.T, name string) *SelfSignedCertKey {
+	ca := &SelfSignedCertKey{}
+	cfg := &CertCfg{
+		Subject:   pkix.Name{CommonName: name, OrganizationalUnit: []string{"test"}},
+		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:  ValidityOneDay,
+		IsCA:      true,
+	}
+	if err := ca.Generate(cfg, name); err != nil {
+		t.Fatalf("failed to generate %s: %v", name, err)
+	}
+	return ca
+}
+
+func TestCertKeyLoadCA(t *testing.T) {
+	ca := generateTestCA(t, "test-ca")
+	otherCA := generateTestCA(t, "other-ca")
+	leaf := &SignedCertKey{}
+	err := leaf.Generate(&CertCfg{
+		Subject:   pkix.Name{CommonName: "leaf"},
+		KeyUsages: x509.KeyUsageDigitalSignature,
+		Validity:  ValidityOneDay,
+	}, ca, "leaf", DoNotAppendParent)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		name          string
+		files         fileFetcher
+		expectedFound bool
+		expectedError string
+	}{
+		{
+			name:  "absent",
+			files: fileFetcher{},
+		},
+		{
+			name: "provided",
+			files: fileFetcher{
+				"tls/test.key": ca.Key(),
+				"tls/test.crt": ca.Cert(),
+			},
+			expectedFound: true,
+		},
+		{
+			name: "key only",
+			files: fileFetcher{
+				"tls/test.key": ca.Key(),
+			},
+			expectedError: "tls/test.key was provided without tls/test.crt",
+		},
+		{
+			name: "cert only",
+			files: fileFetcher{
+				"tls/test.crt": ca.Cert(),
+			},
+			expectedError: "tls/test.crt was provided without tls/test.key",
+		},
+		{
+			name: "not a CA",
+			files: fileFetcher{
+				"tls/test.key": leaf.Key(),
+				"tls/test.crt": leaf.Cert(),
+			},
+			expectedError: "tls/test.crt is not a CA certificate",
+		},
+		{
+			name: "mismatched key",
+			files: fileFetcher{
+				"tls/test.key": otherCA.Key(),
+				"tls/test.crt": ca.Cert(),
+			},
+			expectedError: "tls/test.key does not match tls/test.crt",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			certKey := &CertKey{}
+			found, err := certKey.loadCA(tc.files, "test")
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedFound, found)
+			if found {
+				assert.Equal(t, ca.Key(), certKey.Key())
+				assert.Equal(t, ca.Cert(), certKey.Cert())
+				assert.Len(t, certKey.Files(), 2)
+			}
+		})
+	}
+}
@@ -3,8 +3,10 @@ package tls
 import (
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/pem"
 
 	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
 )
 
 // EtcdSignerCertKey is a key/cert pair that signs the etcd client and peer certs.
@@ -37,7 +39,9 @@ func (c *EtcdSignerCertKey) Name() string {
 }
 
 // EtcdCABundle is the asset the generates the etcd-ca-bundle,
-// which contains all the individual client CAs.
+// which contains all the individual client CAs. With an external etcd, it
+// also contains the CAs of its members, so that the bootstrap node trusts
+// them.
 type EtcdCABundle struct {
 	CertBundle
 }
@@ -48,15 +52,27 @@ var _ asset.Asset = (*EtcdCABundle)(nil)
 func (a *EtcdCABundle) Dependencies() []asset.Asset {
 	return []asset.Asset{
 		&EtcdSignerCertKey{},
+		&installconfig.InstallConfig{},
 	}
 }
 
 // Generate generates the cert bundle based on its dependencies.
 func (a *EtcdCABundle) Generate(deps asset.Parents) error {
-	var certs []CertInterface
-	for _, asset := range a.Dependencies() {
-		deps.Get(asset)
-		certs = append(certs, asset.(CertInterface))
+	signer := &EtcdSignerCertKey{}
+	installConfig := &installconfig.InstallConfig{}
+	deps.Get(signer, installConfig)
+
+	certs := []CertInterface{signer}
+	if installConfig.Config.ExternalEtcd != nil {
+		rest := []byte(installConfig.Config.ExternalEtcd.CABundle)
+		for {
+			var block *pem.Block
+			block, rest = pem.Decode(rest)
+			if block == nil {
+				break
+			}
+			certs = append(certs, &CertKey{CertRaw: pem.EncodeToMemory(block)})
+		}
 	}
 	return a.CertBundle.Generate("etcd-ca-bundle", certs...)
 }
@@ -36,6 +36,14 @@ func (c *EtcdMetricSignerCertKey) Name() string {
 	return "Certificate (etcd-metric-signer)"
 }
 
+// Load loads the etcd-metric-signer key and cert from the target directory,
+// when they are provided there. They are then used in place of the generated
+// ones, so that the etcd metric certificates are issued by a CA that can be
+// rotated apart from the others.
+func (c *EtcdMetricSignerCertKey) Load(f asset.FileFetcher) (bool, error) {
+	return c.loadCA(f, "etcd-metric-signer")
+}
+
 // EtcdMetricCABundle is the asset the generates the etcd-metrics-ca-bundle,
 // which contains all the individual client CAs.
 type EtcdMetricCABundle struct {
@@ -0,0 +1,53 @@
+package tls
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+
+	"github.com/pkg/errors"
+)
+
+// fipsSignatureAlgorithms are the FIPS 186-4 approved certificate signature
+// algorithms.
+var fipsSignatureAlgorithms = map[x509.SignatureAlgorithm]bool{
+	x509.SHA256WithRSA:    true,
+	x509.SHA384WithRSA:    true,
+	x509.SHA512WithRSA:    true,
+	x509.SHA256WithRSAPSS: true,
+	x509.SHA384WithRSAPSS: true,
+	x509.SHA512WithRSAPSS: true,
+	x509.ECDSAWithSHA256:  true,
+	x509.ECDSAWithSHA384:  true,
+	x509.ECDSAWithSHA512:  true,
+}
+
+// VerifyFIPSCertificate returns an error if the PEM-encoded certificate uses
+// a key or signature algorithm that is not FIPS approved.
+func VerifyFIPSCertificate(data []byte) error {
+	cert, err := PemToCertificate(data)
+	if err != nil {
+		return err
+	}
+
+	switch pub := cert.PublicKey.(type) {
+	case *rsa.PublicKey:
+		if bits := pub.N.BitLen(); bits < 2048 {
+			return errors.Errorf("%d-bit RSA keys are not FIPS approved", bits)
+		}
+	case *ecdsa.PublicKey:
+		switch pub.Curve {
+		case elliptic.P256(), elliptic.P384(), elliptic.P521():
+		default:
+			return errors.Errorf("ECDSA curve %s is not FIPS approved", pub.Curve.Params().Name)
+		}
+	default:
+		return errors.Errorf("%s keys are not FIPS approved", cert.PublicKeyAlgorithm)
+	}
+
+	if !fipsSignatureAlgorithms[cert.SignatureAlgorithm] {
+		return errors.Errorf("signature algorithm %s is not FIPS approved", cert.SignatureAlgorithm)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package tls
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyFIPSCertificate(t *testing.T) {
+	cases := []struct {
+		name          string
+		key           func() (crypto.Signer, error)
+		expectedError string
+	}{
+		{
+			name: "RSA-2048",
+			key:  func() (crypto.Signer, error) { return GeneratePrivateKey(RSA2048) },
+		},
+		{
+			name: "ECDSA-P256",
+			key:  func() (crypto.Signer, error) { return GeneratePrivateKey(ECDSAP256) },
+		},
+		{
+			name:          "RSA-1024",
+			key:           func() (crypto.Signer, error) { return rsa.GenerateKey(rand.Reader, 1024) },
+			expectedError: "1024-bit RSA keys are not FIPS approved",
+		},
+		{
+			name:          "ECDSA-P224",
+			key:           func() (crypto.Signer, error) { return ecdsa.GenerateKey(elliptic.P224(), rand.Reader) },
+			expectedError: "ECDSA curve P-224 is not FIPS approved",
+		},
+	}
+	cfg := &CertCfg{
+		Subject:   pkix.Name{CommonName: "fips", OrganizationalUnit: []string{"openshift"}},
+		KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		Validity:  ValidityOneDay,
+		IsCA:      true,
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			key, err := tc.key()
+			if err != nil {
+				t.Fatalf("failed to generate private key: %v", err)
+			}
+			cert, err := SelfSignedCertificate(cfg, key)
+			if err != nil {
+				t.Fatalf("failed to generate certificate: %v", err)
+			}
+			err = VerifyFIPSCertificate(CertToPem(cert))
+			if tc.expectedError == "" {
+				assert.NoError(t, err, "unexpected error")
+			} else {
+				assert.EqualError(t, err, tc.expectedError)
+			}
+		})
+	}
+}
@@ -1,6 +1,8 @@
 package tls
 
 import (
+	"crypto/rsa"
+
 	"github.com/openshift/installer/pkg/asset"
 	"github.com/pkg/errors"
 )
@@ -22,17 +24,30 @@ type KeyPair struct {
 
 // Generate generates the rsa private / public key pair.
 func (k *KeyPair) Generate(filenameBase string) error {
-	key, err := PrivateKey()
+	alg, err := ConfiguredKeyAlgorithm()
+	if err != nil {
+		return err
+	}
+	// The key pair is always RSA, so only the key size is taken from the
+	// configured algorithm.
+	if alg == ECDSAP256 {
+		alg = RSA2048
+	}
+
+	key, err := GeneratePrivateKey(alg)
 	if err != nil {
 		return errors.Wrap(err, "failed to generate private key")
 	}
 
-	pubkeyData, err := PublicKeyToPem(&key.PublicKey)
+	pubkeyData, err := PublicKeyToPem(key.Public().(*rsa.PublicKey))
 	if err != nil {
 		return errors.Wrap(err, "failed to get public key data from private key")
 	}
 
-	k.Pvt = PrivateKeyToPem(key)
+	k.Pvt, err = PrivateKeyToPem(key)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode private key")
+	}
 	k.Pub = pubkeyData
 
 	k.FileList = []*asset.File{
@@ -25,22 +25,35 @@ var _ asset.Asset = (*MCSCertKey)(nil)
 func (a *MCSCertKey) Dependencies() []asset.Asset {
 	return []asset.Asset{
 		&RootCA{},
+		&MCSSignerCertKey{},
 		&installconfig.InstallConfig{},
 	}
 }
 
 // Generate generates the cert/key pair based on its dependencies.
 func (a *MCSCertKey) Generate(dependencies asset.Parents) error {
-	ca := &RootCA{}
+	rootCA := &RootCA{}
+	signer := &MCSSignerCertKey{}
 	installConfig := &installconfig.InstallConfig{}
-	dependencies.Get(ca, installConfig)
+	dependencies.Get(rootCA, signer, installConfig)
+
+	var ca CertKeyInterface = rootCA
+	if signer.Provided() {
+		ca = signer
+	}
 
 	hostname := internalAPIAddress(installConfig.Config)
 
+	keyAlgorithm, err := keyAlgorithmFromEnv(MCSKeyAlgorithmEnvVar)
+	if err != nil {
+		return err
+	}
+
 	cfg := &CertCfg{
 		Subject:      pkix.Name{CommonName: hostname},
 		ExtKeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
 		Validity:     ValidityTenYears,
+		KeyAlgorithm: keyAlgorithm,
 	}
 
 	switch installConfig.Config.Platform.Name() {
@@ -65,3 +78,47 @@ func (a *MCSCertKey) Generate(dependencies asset.Parents) error {
 func (a *MCSCertKey) Name() string {
 	return "Certificate (mcs)"
 }
+
+// MCSSignerCertKey is the CA that was provided in the target directory to
+// sign the MCS key/cert pair in place of the root CA. It is empty when none
+// was provided.
+type MCSSignerCertKey struct {
+	CertKey
+}
+
+var _ asset.WritableAsset = (*MCSSignerCertKey)(nil)
+
+// Dependencies returns the dependency of the MCS signer, which is empty.
+func (a *MCSSignerCertKey) Dependencies() []asset.Asset {
+	return []asset.Asset{}
+}
+
+// Generate is a no-op, because the MCS signer is only ever provided.
+func (a *MCSSignerCertKey) Generate(dependencies asset.Parents) error {
+	return nil
+}
+
+// Name returns the human-friendly name of the asset.
+func (a *MCSSignerCertKey) Name() string {
+	return "Certificate (machine-config-server-signer)"
+}
+
+// Load loads the MCS signer key and cert from the target directory.
+func (a *MCSSignerCertKey) Load(f asset.FileFetcher) (bool, error) {
+	return a.loadCA(f, "machine-config-server-signer")
+}
+
+// Provided returns whether an MCS signer was provided.
+func (a *MCSSignerCertKey) Provided() bool {
+	return len(a.CertRaw) > 0
+}
+
+// MCSCA returns the CA that the machines trust to serve their Ignition configs
+// from the machine config server: the MCS signer when it was provided, and the
+// root CA otherwise.
+func MCSCA(rootCA *RootCA, signer *MCSSignerCertKey) []byte {
+	if signer.Provided() {
+		return signer.Cert()
+	}
+	return rootCA.Cert()
+}
@@ -0,0 +1,184 @@
+package tls
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/asset"
+	"github.com/openshift/installer/pkg/asset/installconfig"
+	"github.com/openshift/installer/pkg/types"
+	nonetypes "github.com/openshift/installer/pkg/types/none"
+)
+
+func TestMCSCertKeyAlgorithm(t *testing.T) {
+	cases := []struct {
+		name              string
+		algorithm         string
+		expectedType      string
+		expectedBlockType string
+	}{
+		{
+			name:              "default",
+			expectedType:      "RSA",
+			expectedBlockType: "RSA PRIVATE KEY",
+		},
+		{
+			name:              "ecdsa",
+			algorithm:         string(ECDSAP256),
+			expectedType:      "ECDSA",
+			expectedBlockType: "EC PRIVATE KEY",
+		},
+	}
+	defer os.Unsetenv(MCSKeyAlgorithmEnvVar)
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			os.Setenv(MCSKeyAlgorithmEnvVar, tc.algorithm)
+
+			rootCA := &RootCA{}
+			if !assert.NoError(t, rootCA.Generate(nil), "failed to generate root CA") {
+				return
+			}
+			installConfig := &installconfig.InstallConfig{
+				Config: &types.InstallConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+					BaseDomain: "test-domain",
+					Platform:   types.Platform{None: &nonetypes.Platform{}},
+				},
+			}
+			parents := asset.Parents{}
+			parents.Add(rootCA, &MCSSignerCertKey{}, installConfig)
+
+			mcs := &MCSCertKey{}
+			if !assert.NoError(t, mcs.Generate(parents), "failed to generate MCS cert") {
+				return
+			}
+
+			cert, err := PemToCertificate(mcs.Cert())
+			if !assert.NoError(t, err, "failed to parse certificate") {
+				return
+			}
+			assert.Equal(t, tc.expectedType, cert.PublicKeyAlgorithm.String(), "unexpected public key algorithm")
+
+			block, _ := pem.Decode(mcs.Key())
+			if assert.NotNil(t, block, "failed to decode private key PEM") {
+				assert.Equal(t, tc.expectedBlockType, block.Type, "unexpected private key PEM block type")
+			}
+
+			rootCert, err := PemToCertificate(rootCA.Cert())
+			if assert.NoError(t, err, "failed to parse root CA certificate") {
+				assert.Equal(t, "RSA", rootCert.PublicKeyAlgorithm.String(), "root CA should not follow the MCS key algorithm")
+			}
+		})
+	}
+}
+
+// chainsTo returns whether the certificate verifies against the CA.
+func chainsTo(t *testing.T, certPEM []byte, ca []byte) bool {
+	cert, err := PemToCertificate(certPEM)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	roots := x509.NewCertPool()
+	roots.AppendCertsFromPEM(ca)
+	_, err = cert.Verify(x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err == nil
+}
+
+func TestSeparateCAs(t *testing.T) {
+	mcsCA := generateTestCA(t, "test-mcs-ca")
+	etcdMetricCA := generateTestCA(t, "test-etcd-metric-ca")
+	files := fileFetcher{
+		"tls/machine-config-server-signer.key": mcsCA.Key(),
+		"tls/machine-config-server-signer.crt": mcsCA.Cert(),
+		"tls/etcd-metric-signer.key":           etcdMetricCA.Key(),
+		"tls/etcd-metric-signer.crt":           etcdMetricCA.Cert(),
+	}
+
+	cases := []struct {
+		name      string
+		separate  bool
+		mcsCA     []byte
+		metricsCA []byte
+	}{
+		{
+			name: "shared",
+		},
+		{
+			name:      "separate",
+			separate:  true,
+			mcsCA:     mcsCA.Cert(),
+			metricsCA: etcdMetricCA.Cert(),
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			rootCA := &RootCA{}
+			if err := rootCA.Generate(nil); err != nil {
+				t.Fatal(err)
+			}
+			mcsSigner := &MCSSignerCertKey{}
+			etcdMetricSigner := &EtcdMetricSignerCertKey{}
+			if tc.separate {
+				for _, a := range []asset.WritableAsset{mcsSigner, etcdMetricSigner} {
+					found, err := a.Load(files)
+					if !assert.NoError(t, err) || !assert.True(t, found, "%s was not loaded", a.Name()) {
+						return
+					}
+				}
+			} else {
+				assert.NoError(t, mcsSigner.Generate(nil))
+				assert.NoError(t, etcdMetricSigner.Generate(nil))
+			}
+			installConfig := &installconfig.InstallConfig{
+				Config: &types.InstallConfig{
+					ObjectMeta: metav1.ObjectMeta{Name: "test-cluster"},
+					BaseDomain: "test-domain",
+					Platform:   types.Platform{None: &nonetypes.Platform{}},
+				},
+			}
+			parents := asset.Parents{}
+			parents.Add(rootCA, mcsSigner, etcdMetricSigner, installConfig)
+
+			mcs := &MCSCertKey{}
+			etcdMetricClient := &EtcdMetricSignerClientCertKey{}
+			etcdMetricBundle := &EtcdMetricCABundle{}
+			for _, a := range []asset.Asset{mcs, etcdMetricClient, etcdMetricBundle} {
+				if err := a.Generate(parents); err != nil {
+					t.Fatalf("failed to generate %s: %v", a.Name(), err)
+				}
+			}
+
+			if !tc.separate {
+				assert.True(t, chainsTo(t, mcs.Cert(), rootCA.Cert()), "the MCS certificate does not chain to the root CA")
+				assert.Equal(t, rootCA.Cert(), MCSCA(rootCA, mcsSigner))
+				assert.True(t, chainsTo(t, etcdMetricClient.Cert(), etcdMetricSigner.Cert()), "the etcd metric certificate does not chain to the etcd-metric-signer")
+				return
+			}
+			assert.True(t, chainsTo(t, mcs.Cert(), tc.mcsCA), "the MCS certificate does not chain to its CA")
+			assert.False(t, chainsTo(t, mcs.Cert(), rootCA.Cert()), "the MCS certificate chains to the root CA")
+			assert.False(t, chainsTo(t, mcs.Cert(), tc.metricsCA), "the MCS certificate chains to the etcd metric CA")
+			assert.Equal(t, tc.mcsCA, MCSCA(rootCA, mcsSigner))
+			assert.True(t, chainsTo(t, etcdMetricClient.Cert(), tc.metricsCA), "the etcd metric certificate does not chain to its CA")
+			assert.False(t, chainsTo(t, etcdMetricClient.Cert(), tc.mcsCA), "the etcd metric certificate chains to the MCS CA")
+			assert.Equal(t, tc.metricsCA, etcdMetricBundle.Cert())
+
+			mcsCert, err := PemToCertificate(mcs.Cert())
+			if err != nil {
+				t.Fatal(err)
+			}
+			etcdMetricCert, err := PemToCertificate(etcdMetricClient.Cert())
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.NotEqual(t, mcsCert.Issuer.String(), etcdMetricCert.Issuer.String())
+		})
+	}
+}
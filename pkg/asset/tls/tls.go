@@ -13,14 +13,13 @@ import (
 	"math"
 	"math/big"
 	"net"
+	"os"
 	"time"
 
 	"github.com/pkg/errors"
 )
 
 const (
-	keySize = 2048
-
 	// ValidityOneDay sets the validity of a cert to 24 hours.
 	ValidityOneDay = time.Hour * 24
 
@@ -40,6 +39,8 @@ type CertCfg struct {
 	Subject      pkix.Name
 	Validity     time.Duration
 	IsCA         bool
+	// KeyAlgorithm overrides the configured key algorithm when set.
+	KeyAlgorithm KeyAlgorithm
 }
 
 // rsaPublicKey reflects the ASN.1 structure of a PKCS#1 public key.
@@ -48,9 +49,94 @@ type rsaPublicKey struct {
 	E int
 }
 
-// PrivateKey generates an RSA Private key and returns the value
-func PrivateKey() (*rsa.PrivateKey, error) {
-	rsaKey, err := rsa.GenerateKey(rand.Reader, keySize)
+// KeyAlgorithm is the algorithm and size of a generated private key.
+type KeyAlgorithm string
+
+const (
+	// RSA2048 is a 2048-bit RSA key.
+	RSA2048 KeyAlgorithm = "RSA-2048"
+	// RSA3072 is a 3072-bit RSA key.
+	RSA3072 KeyAlgorithm = "RSA-3072"
+	// RSA4096 is a 4096-bit RSA key.
+	RSA4096 KeyAlgorithm = "RSA-4096"
+	// ECDSAP256 is an ECDSA key on the NIST P-256 curve.
+	ECDSAP256 KeyAlgorithm = "ECDSA-P256"
+
+	// KeyAlgorithmEnvVar is the environment variable used to select the
+	// algorithm of the generated private keys. It defaults to RSA2048.
+	KeyAlgorithmEnvVar = "OPENSHIFT_INSTALL_TLS_KEY_ALGORITHM"
+
+	// MCSKeyAlgorithmEnvVar is the environment variable used to select the
+	// algorithm of the machine-config-server private key. It defaults to
+	// the algorithm selected by KeyAlgorithmEnvVar.
+	MCSKeyAlgorithmEnvVar = "OPENSHIFT_INSTALL_MCS_KEY_ALGORITHM"
+)
+
+// ConfiguredKeyAlgorithm returns the key algorithm selected by the
+// OPENSHIFT_INSTALL_TLS_KEY_ALGORITHM environment variable.
+func ConfiguredKeyAlgorithm() (KeyAlgorithm, error) {
+	alg, err := keyAlgorithmFromEnv(KeyAlgorithmEnvVar)
+	if err != nil {
+		return "", err
+	}
+	if alg == "" {
+		return RSA2048, nil
+	}
+	return alg, nil
+}
+
+// keyAlgorithmFromEnv returns the key algorithm named by the given
+// environment variable, or an empty algorithm if the variable is unset.
+func keyAlgorithmFromEnv(envVar string) (KeyAlgorithm, error) {
+	alg := KeyAlgorithm(os.Getenv(envVar))
+	switch alg {
+	case "", RSA2048, RSA3072, RSA4096, ECDSAP256:
+		return alg, nil
+	default:
+		return "", errors.Errorf("unsupported key algorithm %q in %s", alg, envVar)
+	}
+}
+
+// PrivateKey generates a private key with the configured key algorithm.
+func PrivateKey() (crypto.Signer, error) {
+	alg, err := ConfiguredKeyAlgorithm()
+	if err != nil {
+		return nil, err
+	}
+	return GeneratePrivateKey(alg)
+}
+
+// certPrivateKey generates the private key for the certificate described by
+// cfg, preferring the algorithm set in cfg over the configured one.
+func certPrivateKey(cfg *CertCfg) (crypto.Signer, error) {
+	if cfg.KeyAlgorithm != "" {
+		return GeneratePrivateKey(cfg.KeyAlgorithm)
+	}
+	return PrivateKey()
+}
+
+// GeneratePrivateKey generates a private key with the given key algorithm.
+func GeneratePrivateKey(alg KeyAlgorithm) (crypto.Signer, error) {
+	switch alg {
+	case RSA2048:
+		return rsaPrivateKey(2048)
+	case RSA3072:
+		return rsaPrivateKey(3072)
+	case RSA4096:
+		return rsaPrivateKey(4096)
+	case ECDSAP256:
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, errors.Wrap(err, "error generating ECDSA private key")
+		}
+		return ecKey, nil
+	default:
+		return nil, errors.Errorf("unsupported key algorithm %q", alg)
+	}
+}
+
+func rsaPrivateKey(bits int) (*rsa.PrivateKey, error) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, bits)
 	if err != nil {
 		return nil, errors.Wrap(err, "error generating RSA private key")
 	}
@@ -59,7 +145,7 @@ func PrivateKey() (*rsa.PrivateKey, error) {
 }
 
 // SelfSignedCertificate creates a self signed certificate
-func SelfSignedCertificate(cfg *CertCfg, key *rsa.PrivateKey) (*x509.Certificate, error) {
+func SelfSignedCertificate(cfg *CertCfg, key crypto.Signer) (*x509.Certificate, error) {
 	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
 	if err != nil {
 		return nil, err
@@ -93,9 +179,9 @@ func SelfSignedCertificate(cfg *CertCfg, key *rsa.PrivateKey) (*x509.Certificate
 func SignedCertificate(
 	cfg *CertCfg,
 	csr *x509.CertificateRequest,
-	key *rsa.PrivateKey,
+	key crypto.Signer,
 	caCert *x509.Certificate,
-	caKey *rsa.PrivateKey,
+	caKey crypto.Signer,
 ) (*x509.Certificate, error) {
 	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
 	if err != nil {
@@ -115,8 +201,7 @@ func SignedCertificate(
 		Version:               3,
 		BasicConstraintsValid: true,
 	}
-	pub := caCert.PublicKey.(*rsa.PublicKey)
-	certTmpl.SubjectKeyId, err = generateSubjectKeyID(pub)
+	certTmpl.SubjectKeyId, err = generateSubjectKeyID(caCert.PublicKey)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to set subject key identifier")
 	}
@@ -149,11 +234,11 @@ func generateSubjectKeyID(pub crypto.PublicKey) ([]byte, error) {
 }
 
 // GenerateSignedCertificate generate a key and cert defined by CertCfg and signed by CA.
-func GenerateSignedCertificate(caKey *rsa.PrivateKey, caCert *x509.Certificate,
-	cfg *CertCfg) (*rsa.PrivateKey, *x509.Certificate, error) {
+func GenerateSignedCertificate(caKey crypto.Signer, caCert *x509.Certificate,
+	cfg *CertCfg) (crypto.Signer, *x509.Certificate, error) {
 
 	// create a private key
-	key, err := PrivateKey()
+	key, err := certPrivateKey(cfg)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to generate private key")
 	}
@@ -178,8 +263,8 @@ func GenerateSignedCertificate(caKey *rsa.PrivateKey, caCert *x509.Certificate,
 }
 
 // GenerateSelfSignedCertificate generates a key/cert pair defined by CertCfg.
-func GenerateSelfSignedCertificate(cfg *CertCfg) (*rsa.PrivateKey, *x509.Certificate, error) {
-	key, err := PrivateKey()
+func GenerateSelfSignedCertificate(cfg *CertCfg) (crypto.Signer, *x509.Certificate, error) {
+	key, err := certPrivateKey(cfg)
 	if err != nil {
 		return nil, nil, errors.Wrap(err, "failed to generate private key")
 	}
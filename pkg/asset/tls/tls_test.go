@@ -1,11 +1,16 @@
 package tls
 
 import (
+	"crypto/ecdsa"
 	"crypto/rand"
+	"crypto/rsa"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"os"
 	"testing"
 	"time"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestSelfSignedCertificate(t *testing.T) {
@@ -105,3 +110,85 @@ func TestSignedCertificate(t *testing.T) {
 		}
 	}
 }
+
+func TestKeyAlgorithm(t *testing.T) {
+	cases := []struct {
+		algorithm       string
+		expectedType    x509.PublicKeyAlgorithm
+		expectedBitSize int
+		expectedError   string
+	}{
+		{
+			algorithm:       "",
+			expectedType:    x509.RSA,
+			expectedBitSize: 2048,
+		},
+		{
+			algorithm:       "RSA-2048",
+			expectedType:    x509.RSA,
+			expectedBitSize: 2048,
+		},
+		{
+			algorithm:       "RSA-3072",
+			expectedType:    x509.RSA,
+			expectedBitSize: 3072,
+		},
+		{
+			algorithm:       "RSA-4096",
+			expectedType:    x509.RSA,
+			expectedBitSize: 4096,
+		},
+		{
+			algorithm:       "ECDSA-P256",
+			expectedType:    x509.ECDSA,
+			expectedBitSize: 256,
+		},
+		{
+			algorithm:     "DSA-1024",
+			expectedError: `failed to generate self-signed cert/key pair: failed to generate private key: unsupported key algorithm "DSA-1024" in OPENSHIFT_INSTALL_TLS_KEY_ALGORITHM`,
+		},
+	}
+	defer os.Unsetenv(KeyAlgorithmEnvVar)
+	for _, tc := range cases {
+		t.Run(tc.algorithm, func(t *testing.T) {
+			os.Setenv(KeyAlgorithmEnvVar, tc.algorithm)
+
+			rootCA := &RootCA{}
+			err := rootCA.Generate(nil)
+			if tc.expectedError != "" {
+				assert.EqualError(t, err, tc.expectedError)
+				return
+			}
+			if !assert.NoError(t, err, "failed to generate root CA") {
+				return
+			}
+
+			certKey := &SignedCertKey{}
+			err = certKey.Generate(&CertCfg{
+				Subject:   pkix.Name{CommonName: "test", OrganizationalUnit: []string{"openshift"}},
+				KeyUsages: x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+				Validity:  ValidityOneDay,
+			}, rootCA, "test", DoNotAppendParent)
+			if !assert.NoError(t, err, "failed to generate signed cert") {
+				return
+			}
+
+			for _, ck := range []CertKeyInterface{rootCA, certKey} {
+				cert, err := PemToCertificate(ck.Cert())
+				if !assert.NoError(t, err, "failed to parse certificate") {
+					return
+				}
+				assert.Equal(t, tc.expectedType, cert.PublicKeyAlgorithm, "unexpected public key algorithm")
+				switch pub := cert.PublicKey.(type) {
+				case *rsa.PublicKey:
+					assert.Equal(t, tc.expectedBitSize, pub.N.BitLen(), "unexpected key size")
+				case *ecdsa.PublicKey:
+					assert.Equal(t, tc.expectedBitSize, pub.Curve.Params().BitSize, "unexpected key size")
+				}
+
+				_, err = PemToPrivateKey(ck.Key())
+				assert.NoError(t, err, "failed to parse private key")
+			}
+		})
+	}
+}
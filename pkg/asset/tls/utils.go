@@ -1,6 +1,8 @@
 package tls
 
 import (
+	"crypto"
+	"crypto/ecdsa"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -8,16 +10,29 @@ import (
 	"github.com/pkg/errors"
 )
 
-// PrivateKeyToPem converts an rsa.PrivateKey object to pem string
-func PrivateKeyToPem(key *rsa.PrivateKey) []byte {
-	keyInBytes := x509.MarshalPKCS1PrivateKey(key)
-	keyinPem := pem.EncodeToMemory(
-		&pem.Block{
+// PrivateKeyToPem converts an rsa.PrivateKey or ecdsa.PrivateKey object to
+// pem string
+func PrivateKeyToPem(key crypto.Signer) ([]byte, error) {
+	var block *pem.Block
+	switch key := key.(type) {
+	case *rsa.PrivateKey:
+		block = &pem.Block{
 			Type:  "RSA PRIVATE KEY",
+			Bytes: x509.MarshalPKCS1PrivateKey(key),
+		}
+	case *ecdsa.PrivateKey:
+		keyInBytes, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to MarshalECPrivateKey")
+		}
+		block = &pem.Block{
+			Type:  "EC PRIVATE KEY",
 			Bytes: keyInBytes,
-		},
-	)
-	return keyinPem
+		}
+	default:
+		return nil, errors.Errorf("unsupported private key type %T", key)
+	}
+	return pem.EncodeToMemory(block), nil
 }
 
 // CertToPem converts an x509.Certificate object to a pem string
@@ -57,13 +72,19 @@ func PublicKeyToPem(key *rsa.PublicKey) ([]byte, error) {
 	return keyinPem, nil
 }
 
-// PemToPrivateKey converts a data block to rsa.PrivateKey.
-func PemToPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+// PemToPrivateKey converts a data block to rsa.PrivateKey or
+// ecdsa.PrivateKey.
+func PemToPrivateKey(data []byte) (crypto.Signer, error) {
 	block, _ := pem.Decode(data)
 	if block == nil {
 		return nil, errors.Errorf("could not find a PEM block in the private key")
 	}
-	return x509.ParsePKCS1PrivateKey(block.Bytes)
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	default:
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
 }
 
 // PemToCertificate converts a data block to x509.Certificate.
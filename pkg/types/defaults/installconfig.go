@@ -14,11 +14,12 @@ import (
 )
 
 var (
-	defaultMachineCIDR    = ipnet.MustParseCIDR("10.0.0.0/16")
-	defaultServiceNetwork = ipnet.MustParseCIDR("172.30.0.0/16")
-	defaultClusterNetwork = ipnet.MustParseCIDR("10.128.0.0/14")
-	defaultHostPrefix     = 23
-	defaultNetworkType    = "OpenShiftSDN"
+	defaultMachineCIDR        = ipnet.MustParseCIDR("10.0.0.0/16")
+	defaultServiceNetwork     = ipnet.MustParseCIDR("172.30.0.0/16")
+	defaultClusterNetwork     = ipnet.MustParseCIDR("10.128.0.0/14")
+	defaultHostPrefix         = 23
+	defaultNetworkType        = "OpenShiftSDN"
+	defaultEtcdBackupSchedule = "0 0 * * *"
 )
 
 // SetInstallConfigDefaults sets the defaults for the install config.
@@ -47,6 +48,10 @@ func SetInstallConfigDefaults(c *types.InstallConfig) {
 		}
 	}
 
+	if c.EtcdBackup != nil && c.EtcdBackup.Schedule == "" {
+		c.EtcdBackup.Schedule = defaultEtcdBackupSchedule
+	}
+
 	if c.Publish == "" {
 		c.Publish = types.ExternalPublishingStrategy
 	}
@@ -198,6 +198,28 @@ func TestSetInstallConfigDefaults(t *testing.T) {
 				return c
 			}(),
 		},
+		{
+			name: "etcd backup present",
+			config: &types.InstallConfig{
+				EtcdBackup: &types.EtcdBackup{Image: "quay.io/example/etcd"},
+			},
+			expected: func() *types.InstallConfig {
+				c := defaultInstallConfig()
+				c.EtcdBackup = &types.EtcdBackup{Schedule: defaultEtcdBackupSchedule, Image: "quay.io/example/etcd"}
+				return c
+			}(),
+		},
+		{
+			name: "etcd backup schedule present",
+			config: &types.InstallConfig{
+				EtcdBackup: &types.EtcdBackup{Schedule: "@hourly", Image: "quay.io/example/etcd"},
+			},
+			expected: func() *types.InstallConfig {
+				c := defaultInstallConfig()
+				c.EtcdBackup = &types.EtcdBackup{Schedule: "@hourly", Image: "quay.io/example/etcd"}
+				return c
+			}(),
+		},
 		{
 			name: "AWS platform present",
 			config: &types.InstallConfig{
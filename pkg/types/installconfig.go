@@ -2,6 +2,7 @@ package types
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/openshift/installer/pkg/ipnet"
 	"github.com/openshift/installer/pkg/types/aws"
@@ -20,6 +21,10 @@ const (
 	// If you bump this, you must also update the list of convertable values in
 	// pkg/types/conversion/installconfig.go
 	InstallConfigVersion = "v1"
+
+	// PullSecretFilePrefix marks a pull secret given as the path of the file
+	// holding it.
+	PullSecretFilePrefix = "@"
 )
 
 var (
@@ -89,7 +94,9 @@ type InstallConfig struct {
 	// perform the installation.
 	Platform `json:"platform"`
 
-	// PullSecret is the secret to use when pulling images.
+	// PullSecret is the secret to use when pulling images. It may instead
+	// be given as @ followed by the path of a file holding the secret, such
+	// as @pull-secret.json, which is read when the secret is used.
 	PullSecret string `json:"pullSecret"`
 
 	// Proxy defines the proxy settings for the cluster.
@@ -101,6 +108,59 @@ type InstallConfig struct {
 	// +optional
 	ImageContentSources []ImageContentSource `json:"imageContentSources,omitempty"`
 
+	// EtcdDNSSuffix is the DNS domain under which the etcd members are
+	// resolved. When unset, the cluster domain is used.
+	// +optional
+	EtcdDNSSuffix string `json:"etcdDNSSuffix,omitempty"`
+
+	// EtcdMemberHostnames are the DNS labels of the in-cluster etcd members,
+	// one for each control-plane replica, for clusters whose DNS is managed
+	// outside of the installer. They are resolved under etcdDNSSuffix, or
+	// under the cluster domain when it is unset, and default to etcd-0,
+	// etcd-1 and so on.
+	// +optional
+	EtcdMemberHostnames []string `json:"etcdMemberHostnames,omitempty"`
+
+	// ExternalEtcd configures the cluster to use an etcd cluster running
+	// outside of it instead of the in-cluster etcd members.
+	// +optional
+	ExternalEtcd *ExternalEtcd `json:"externalEtcd,omitempty"`
+
+	// EtcdBackup configures a CronJob that periodically snapshots the
+	// in-cluster etcd. No backups are taken when it is unset.
+	// +optional
+	EtcdBackup *EtcdBackup `json:"etcdBackup,omitempty"`
+
+	// AlertReceiver configures where Alertmanager sends the alerts of the
+	// cluster. When it is unset, the alerts are not sent anywhere until a
+	// receiver is configured on day 2.
+	// +optional
+	AlertReceiver *AlertReceiver `json:"alertReceiver,omitempty"`
+
+	// ClusterMonitoring, when set, expects the cluster monitoring stack to
+	// scrape the in-cluster etcd members, and generates the ServiceMonitor
+	// of their metrics.
+	// +optional
+	ClusterMonitoring bool `json:"clusterMonitoring,omitempty"`
+
+	// ResourceQuotas are the quotas of the namespaces the installer creates,
+	// such as openshift-machine-config-operator, with at most one quota per
+	// namespace. No quotas are created when it is unset.
+	// +optional
+	ResourceQuotas []NamespaceResourceQuota `json:"resourceQuotas,omitempty"`
+
+	// ClusterVersionOverrides lists additional overrides for the cluster
+	// version operator. They are added to the overrides required by the
+	// installer.
+	// +optional
+	ClusterVersionOverrides []ClusterVersionOverride `json:"clusterVersionOverrides,omitempty"`
+
+	// FeatureGates are the names of the OpenShift feature gates enabled for
+	// the cluster, such as TechPreview. Day-1 manifests that require a
+	// feature gate are only generated when it is enabled.
+	// +optional
+	FeatureGates []string `json:"featureGates,omitempty"`
+
 	// Publish controls how the user facing endpoints of the cluster like the Kubernetes API, OpenShift routes etc. are exposed.
 	// When no strategy is specified, the strategy is `External`.
 	// +optional
@@ -110,11 +170,48 @@ type InstallConfig struct {
 	FIPS bool `json:"fips,omitempty"`
 }
 
+// FeatureGateEnabled returns whether the named feature gate is enabled.
+func (c *InstallConfig) FeatureGateEnabled(name string) bool {
+	for _, gate := range c.FeatureGates {
+		if gate == name {
+			return true
+		}
+	}
+	return false
+}
+
+// EtcdMemberHostname returns the DNS label of the etcd member of the
+// control-plane replica with the given index.
+func (c *InstallConfig) EtcdMemberHostname(index int) string {
+	if index < len(c.EtcdMemberHostnames) {
+		return c.EtcdMemberHostnames[index]
+	}
+	return fmt.Sprintf("etcd-%d", index)
+}
+
+// PullSecretFile returns the path of the file holding the pull secret, when
+// the pull secret is given as a file reference, or an empty string.
+func (c *InstallConfig) PullSecretFile() string {
+	if !strings.HasPrefix(c.PullSecret, PullSecretFilePrefix) {
+		return ""
+	}
+	return strings.TrimPrefix(c.PullSecret, PullSecretFilePrefix)
+}
+
 // ClusterDomain returns the DNS domain that all records for a cluster must belong to.
 func (c *InstallConfig) ClusterDomain() string {
 	return fmt.Sprintf("%s.%s", c.ObjectMeta.Name, c.BaseDomain)
 }
 
+// EtcdDiscoveryDomain returns the DNS domain under which the etcd members are
+// resolved: the etcd DNS suffix when it is set, and the cluster domain otherwise.
+func (c *InstallConfig) EtcdDiscoveryDomain() string {
+	if c.EtcdDNSSuffix != "" {
+		return c.EtcdDNSSuffix
+	}
+	return c.ClusterDomain()
+}
+
 // Platform is the configuration for the specific platform upon which to perform
 // the installation. Only one of the platform configuration should be set.
 type Platform struct {
@@ -257,4 +354,112 @@ type ImageContentSource struct {
 	// Mirrors is one or more repositories that may also contain the same images.
 	// +optional
 	Mirrors []string `json:"mirrors,omitempty"`
+
+	// CopySourceCredentials, when set, gives each mirror host without an
+	// entry of its own in the pull secret the credentials of the source's
+	// registry host. When unset, the mirrors are only authenticated to with
+	// the entries that the pull secret holds for them.
+	// +optional
+	CopySourceCredentials bool `json:"copySourceCredentials,omitempty"`
+}
+
+// ExternalEtcd describes an etcd cluster running outside of the cluster.
+type ExternalEtcd struct {
+	// Hostnames are the DNS labels of the etcd members, which are resolved
+	// under etcdDNSSuffix, or under the cluster domain when it is unset.
+	Hostnames []string `json:"hostnames"`
+
+	// CABundle is the PEM-encoded bundle of the CAs that signed the serving
+	// certificates of the etcd members. The members must trust the etcd
+	// client certificate generated by the installer.
+	CABundle string `json:"caBundle"`
+}
+
+// EtcdBackup describes the periodic snapshots of the in-cluster etcd.
+type EtcdBackup struct {
+	// Schedule is when the snapshots are taken, in the cron format of the
+	// Kubernetes CronJob. Defaults to daily at midnight.
+	// +optional
+	Schedule string `json:"schedule,omitempty"`
+
+	// Image is the pull spec of an image providing etcdctl, which takes the
+	// snapshots.
+	Image string `json:"image"`
+}
+
+// AlertReceiver describes the receiver of the alerts of the cluster. Exactly
+// one kind of receiver must be set.
+type AlertReceiver struct {
+	// Slack sends the alerts to a Slack incoming webhook.
+	// +optional
+	Slack *SlackAlertReceiver `json:"slack,omitempty"`
+
+	// Email sends the alerts by email.
+	// +optional
+	Email *EmailAlertReceiver `json:"email,omitempty"`
+}
+
+// SlackAlertReceiver describes a Slack incoming webhook.
+type SlackAlertReceiver struct {
+	// APIURL is the https URL of the webhook.
+	APIURL string `json:"apiURL"`
+
+	// Channel is the channel, or the user, the alerts are posted to.
+	Channel string `json:"channel"`
+}
+
+// EmailAlertReceiver describes the mail sent for the alerts.
+type EmailAlertReceiver struct {
+	// To is the address the alerts are sent to.
+	To string `json:"to"`
+
+	// From is the sender address of the alerts.
+	From string `json:"from"`
+
+	// Smarthost is the host:port of the SMTP server the alerts are sent
+	// through.
+	Smarthost string `json:"smarthost"`
+
+	// AuthUsername is the user to authenticate to the SMTP server as.
+	// +optional
+	AuthUsername string `json:"authUsername,omitempty"`
+
+	// AuthPassword is the password of AuthUsername.
+	// +optional
+	AuthPassword string `json:"authPassword,omitempty"`
+}
+
+// NamespaceResourceQuota describes the ResourceQuota of a namespace the
+// installer creates.
+type NamespaceResourceQuota struct {
+	// Namespace is the name of the namespace.
+	Namespace string `json:"namespace"`
+
+	// Hard maps the resources, such as requests.cpu or pods, to the
+	// quantity of them the namespace may use at most.
+	Hard map[string]string `json:"hard"`
+}
+
+// ClusterVersionOverride overrides the cluster version operator's behavior
+// for a component.
+type ClusterVersionOverride struct {
+	// Kind identifies which object to override.
+	Kind string `json:"kind"`
+
+	// Group identifies the API group that the kind is in.
+	// +optional
+	Group string `json:"group,omitempty"`
+
+	// Namespace is the component's namespace. If the resource is cluster
+	// scoped, the namespace should be empty.
+	// +optional
+	Namespace string `json:"namespace,omitempty"`
+
+	// Name is the component's name.
+	Name string `json:"name"`
+
+	// Unmanaged controls if the cluster version operator should stop managing
+	// the resources in this cluster.
+	// +optional
+	Unmanaged bool `json:"unmanaged,omitempty"`
 }
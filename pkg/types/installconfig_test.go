@@ -13,3 +13,39 @@ func TestPlatformNamesSorted(t *testing.T) {
 	sort.Strings(sorted)
 	assert.Equal(t, sorted, PlatformNames)
 }
+
+func TestEtcdMemberHostname(t *testing.T) {
+	c := &InstallConfig{}
+	assert.Equal(t, "etcd-0", c.EtcdMemberHostname(0))
+	assert.Equal(t, "etcd-2", c.EtcdMemberHostname(2))
+
+	c.EtcdMemberHostnames = []string{"etcd-a", "etcd-b", "etcd-c"}
+	assert.Equal(t, "etcd-a", c.EtcdMemberHostname(0))
+	assert.Equal(t, "etcd-c", c.EtcdMemberHostname(2))
+}
+
+func TestEtcdDiscoveryDomain(t *testing.T) {
+	c := &InstallConfig{BaseDomain: "test-domain"}
+	c.ObjectMeta.Name = "test-cluster"
+	assert.Equal(t, "test-cluster.test-domain", c.EtcdDiscoveryDomain())
+
+	c.EtcdDNSSuffix = "etcd.internal.example.com"
+	assert.Equal(t, "etcd.internal.example.com", c.EtcdDiscoveryDomain())
+}
+
+func TestPullSecretFile(t *testing.T) {
+	c := &InstallConfig{PullSecret: `{"auths":{}}`}
+	assert.Equal(t, "", c.PullSecretFile())
+
+	c.PullSecret = "@/etc/pull-secret.json"
+	assert.Equal(t, "/etc/pull-secret.json", c.PullSecretFile())
+}
+
+func TestFeatureGateEnabled(t *testing.T) {
+	c := &InstallConfig{}
+	assert.False(t, c.FeatureGateEnabled("TechPreview"))
+
+	c.FeatureGates = []string{"CustomNoUpgrade", "TechPreview"}
+	assert.True(t, c.FeatureGateEnabled("TechPreview"))
+	assert.False(t, c.FeatureGateEnabled("techpreview"))
+}
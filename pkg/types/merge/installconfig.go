@@ -0,0 +1,101 @@
+// Package merge deep-merges install-config fragments, so a base
+// install-config can be shared by several environments that each override
+// a few of its fields.
+package merge
+
+import (
+	"encoding/json"
+
+	"github.com/ghodss/yaml"
+	"github.com/pkg/errors"
+
+	"github.com/openshift/installer/pkg/types"
+)
+
+// SliceStrategy is how the lists of an override are merged into the lists
+// they override.
+type SliceStrategy string
+
+const (
+	// ReplaceSlices replaces the overridden list with the list of the
+	// override. It is the default.
+	ReplaceSlices SliceStrategy = "Replace"
+
+	// AppendSlices appends the items of the override to the overridden list.
+	AppendSlices SliceStrategy = "Append"
+)
+
+// InstallConfig returns the base install-config with the YAML override
+// fragments merged into it. The fragments are merged in order, so each
+// fragment takes precedence over the base and over the fragments before it:
+//
+//   - objects, such as the platform, are merged field by field;
+//   - lists, such as the compute pools, are replaced or appended according to
+//     the strategy;
+//   - any other value replaces the overridden value, and null removes it.
+//
+// The base is not modified. The merged install-config is neither defaulted
+// nor validated, so it can be loaded as any other install-config.
+func InstallConfig(base *types.InstallConfig, overrides [][]byte, slices SliceStrategy) (*types.InstallConfig, error) {
+	switch slices {
+	case "", ReplaceSlices, AppendSlices:
+	default:
+		return nil, errors.Errorf("unsupported slice strategy %q", slices)
+	}
+
+	data, err := json.Marshal(base)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the base install-config")
+	}
+	var merged map[string]interface{}
+	if err := json.Unmarshal(data, &merged); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the base install-config")
+	}
+
+	for i, override := range overrides {
+		var fragment map[string]interface{}
+		if err := yaml.Unmarshal(override, &fragment); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal override %d", i)
+		}
+		merged = mergeObjects(merged, fragment, slices)
+	}
+
+	data, err = json.Marshal(merged)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal the merged install-config")
+	}
+	config := &types.InstallConfig{}
+	if err := json.Unmarshal(data, config); err != nil {
+		return nil, errors.Wrap(err, "failed to unmarshal the merged install-config")
+	}
+	return config, nil
+}
+
+// mergeObjects merges the override into the base, which it modifies.
+func mergeObjects(base, override map[string]interface{}, slices SliceStrategy) map[string]interface{} {
+	if base == nil {
+		base = map[string]interface{}{}
+	}
+	for k, v := range override {
+		if v == nil {
+			delete(base, k)
+			continue
+		}
+		base[k] = mergeValues(base[k], v, slices)
+	}
+	return base
+}
+
+func mergeValues(base, override interface{}, slices SliceStrategy) interface{} {
+	switch o := override.(type) {
+	case map[string]interface{}:
+		if b, ok := base.(map[string]interface{}); ok {
+			return mergeObjects(b, o, slices)
+		}
+	case []interface{}:
+		if b, ok := base.([]interface{}); ok && slices == AppendSlices {
+			return append(b, o...)
+		}
+	}
+	return override
+}
@@ -0,0 +1,135 @@
+package merge
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/openshift/installer/pkg/types"
+	"github.com/openshift/installer/pkg/types/aws"
+)
+
+func int64p(i int64) *int64 {
+	return &i
+}
+
+func baseInstallConfig() *types.InstallConfig {
+	return &types.InstallConfig{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: types.InstallConfigVersion,
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-cluster",
+		},
+		BaseDomain: "dev.example.com",
+		Compute: []types.MachinePool{
+			{
+				Name:     "worker",
+				Replicas: int64p(3),
+			},
+		},
+		Platform: types.Platform{
+			AWS: &aws.Platform{
+				Region: "us-east-1",
+				UserTags: map[string]string{
+					"team": "installer",
+				},
+			},
+		},
+	}
+}
+
+func TestInstallConfig(t *testing.T) {
+	cases := []struct {
+		name          string
+		overrides     []string
+		slices        SliceStrategy
+		expected      func(*types.InstallConfig)
+		expectedError string
+	}{
+		{
+			name:     "no overrides",
+			expected: func(*types.InstallConfig) {},
+		},
+		{
+			name:      "base domain",
+			overrides: []string{"baseDomain: prod.example.com\n"},
+			expected: func(c *types.InstallConfig) {
+				c.BaseDomain = "prod.example.com"
+			},
+		},
+		{
+			name:      "later overrides take precedence",
+			overrides: []string{"baseDomain: stage.example.com\n", "baseDomain: prod.example.com\n"},
+			expected: func(c *types.InstallConfig) {
+				c.BaseDomain = "prod.example.com"
+			},
+		},
+		{
+			name:      "nested platform",
+			overrides: []string{"platform:\n  aws:\n    region: eu-west-1\n    userTags:\n      env: prod\n"},
+			expected: func(c *types.InstallConfig) {
+				c.Platform.AWS.Region = "eu-west-1"
+				c.Platform.AWS.UserTags["env"] = "prod"
+			},
+		},
+		{
+			name:      "null removes a value",
+			overrides: []string{"platform:\n  aws:\n    userTags: null\n"},
+			expected: func(c *types.InstallConfig) {
+				c.Platform.AWS.UserTags = nil
+			},
+		},
+		{
+			name:      "replaced machine pools",
+			overrides: []string{"compute:\n- name: infra\n  replicas: 2\n"},
+			expected: func(c *types.InstallConfig) {
+				c.Compute = []types.MachinePool{{Name: "infra", Replicas: int64p(2)}}
+			},
+		},
+		{
+			name: "appended machine pool",
+			overrides: []string{
+				"baseDomain: prod.example.com\n",
+				"compute:\n- name: infra\n  replicas: 2\n",
+			},
+			slices: AppendSlices,
+			expected: func(c *types.InstallConfig) {
+				c.BaseDomain = "prod.example.com"
+				c.Compute = append(c.Compute, types.MachinePool{Name: "infra", Replicas: int64p(2)})
+			},
+		},
+		{
+			name:          "invalid override",
+			overrides:     []string{"baseDomain: [prod\n"},
+			expectedError: `^failed to unmarshal override 0: .*`,
+		},
+		{
+			name:          "unsupported strategy",
+			slices:        "Prepend",
+			expectedError: `^unsupported slice strategy "Prepend"$`,
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			base := baseInstallConfig()
+			overrides := make([][]byte, len(tc.overrides))
+			for i, o := range tc.overrides {
+				overrides[i] = []byte(o)
+			}
+			merged, err := InstallConfig(base, overrides, tc.slices)
+			if tc.expectedError != "" {
+				assert.Regexp(t, tc.expectedError, err)
+				return
+			}
+			if !assert.NoError(t, err) {
+				return
+			}
+			expected := baseInstallConfig()
+			tc.expected(expected)
+			assert.Equal(t, expected, merged)
+			assert.Equal(t, baseInstallConfig(), base, "the base was modified")
+		})
+	}
+}
@@ -3,11 +3,14 @@ package validation
 import (
 	"fmt"
 	"net"
+	"net/mail"
 	"sort"
 	"strings"
 
 	dockerref "github.com/containers/image/docker/reference"
 	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	"github.com/openshift/installer/pkg/types"
@@ -74,6 +77,20 @@ func ValidateInstallConfig(c *types.InstallConfig, openStackValidValuesFetcher o
 			allErrs = append(allErrs, field.Invalid(field.NewPath("baseDomain"), clusterDomain, err.Error()))
 		}
 	}
+	if c.EtcdDNSSuffix != "" {
+		if err := validate.DomainName(c.EtcdDNSSuffix, true); err != nil {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("etcdDNSSuffix"), c.EtcdDNSSuffix, err.Error()))
+		}
+	}
+	if len(c.EtcdMemberHostnames) > 0 {
+		allErrs = append(allErrs, validateEtcdMemberHostnames(c, field.NewPath("etcdMemberHostnames"))...)
+	}
+	if c.ExternalEtcd != nil {
+		allErrs = append(allErrs, validateExternalEtcd(c.ExternalEtcd, field.NewPath("externalEtcd"))...)
+	}
+	if c.EtcdBackup != nil {
+		allErrs = append(allErrs, validateEtcdBackup(c, field.NewPath("etcdBackup"))...)
+	}
 	if c.Networking != nil {
 		allErrs = append(allErrs, validateNetworking(c.Networking, field.NewPath("networking"))...)
 	} else {
@@ -86,13 +103,23 @@ func ValidateInstallConfig(c *types.InstallConfig, openStackValidValuesFetcher o
 		allErrs = append(allErrs, field.Required(field.NewPath("controlPlane"), "controlPlane is required"))
 	}
 	allErrs = append(allErrs, validateCompute(&c.Platform, c.Compute, field.NewPath("compute"))...)
-	if err := validate.ImagePullSecret(c.PullSecret); err != nil {
+	// A pull secret given as a file reference is read when the
+	// install-config is loaded, so the secret itself is validated.
+	if c.PullSecret == types.PullSecretFilePrefix {
+		allErrs = append(allErrs, field.Required(field.NewPath("pullSecret"), "the pull secret file path is required"))
+	} else if err := validate.ImagePullSecret(c.PullSecret); err != nil {
 		allErrs = append(allErrs, field.Invalid(field.NewPath("pullSecret"), c.PullSecret, err.Error()))
 	}
 	if c.Proxy != nil {
 		allErrs = append(allErrs, validateProxy(c.Proxy, field.NewPath("proxy"))...)
 	}
 	allErrs = append(allErrs, validateImageContentSources(c.ImageContentSources, field.NewPath("imageContentSources"))...)
+	if c.AlertReceiver != nil {
+		allErrs = append(allErrs, validateAlertReceiver(c.AlertReceiver, field.NewPath("alertReceiver"))...)
+	}
+	allErrs = append(allErrs, validateResourceQuotas(c.ResourceQuotas, field.NewPath("resourceQuotas"))...)
+	allErrs = append(allErrs, validateClusterVersionOverrides(c.ClusterVersionOverrides, field.NewPath("clusterVersionOverrides"))...)
+	allErrs = append(allErrs, validateFeatureGates(c.FeatureGates, field.NewPath("featureGates"))...)
 	if _, ok := validPublishingStrategies[c.Publish]; !ok {
 		allErrs = append(allErrs, field.NotSupported(field.NewPath("publish"), c.Publish, validPublishingStrategyValues))
 	}
@@ -299,6 +326,206 @@ func validateImageContentSources(groups []types.ImageContentSource, fldPath *fie
 	return allErrs
 }
 
+func validateExternalEtcd(e *types.ExternalEtcd, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(e.Hostnames) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("hostnames"), "hostnames are required for an external etcd"))
+	}
+	allErrs = append(allErrs, validateEtcdHostnames(e.Hostnames, fldPath.Child("hostnames"))...)
+	if e.CABundle == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("caBundle"), "caBundle is required for an external etcd"))
+	} else if err := validate.CABundle(e.CABundle); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("caBundle"), e.CABundle, err.Error()))
+	}
+	return allErrs
+}
+
+// validateEtcdBackup validates the periodic snapshots of the in-cluster
+// etcd, which cannot be taken of an external etcd.
+func validateEtcdBackup(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if c.ExternalEtcd != nil {
+		return append(allErrs, field.Forbidden(fldPath, "etcdBackup cannot be set for an external etcd, which is not backed up by the cluster"))
+	}
+	if c.EtcdBackup.Schedule == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("schedule"), "schedule is required for etcd backups"))
+	} else if err := validate.CronSchedule(c.EtcdBackup.Schedule); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("schedule"), c.EtcdBackup.Schedule, err.Error()))
+	}
+	if c.EtcdBackup.Image == "" {
+		allErrs = append(allErrs, field.Required(fldPath.Child("image"), "image is required for etcd backups"))
+	} else if _, err := dockerref.ParseNamed(c.EtcdBackup.Image); err != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("image"), c.EtcdBackup.Image, err.Error()))
+	}
+	return allErrs
+}
+
+// validateEtcdMemberHostnames validates the custom hostnames of the
+// in-cluster etcd members, which must name one member for each
+// control-plane replica.
+func validateEtcdMemberHostnames(c *types.InstallConfig, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if c.ExternalEtcd != nil {
+		return append(allErrs, field.Forbidden(fldPath, "etcdMemberHostnames cannot be set for an external etcd, whose members are named by externalEtcd.hostnames"))
+	}
+	if c.ControlPlane != nil && c.ControlPlane.Replicas != nil && int64(len(c.EtcdMemberHostnames)) != *c.ControlPlane.Replicas {
+		allErrs = append(allErrs, field.Invalid(fldPath, c.EtcdMemberHostnames, fmt.Sprintf("%d hostnames are required, one for each control plane replica", *c.ControlPlane.Replicas)))
+	}
+	return append(allErrs, validateEtcdHostnames(c.EtcdMemberHostnames, fldPath)...)
+}
+
+// validateEtcdHostnames validates that the hostnames of the etcd members are
+// distinct DNS labels.
+func validateEtcdHostnames(hostnames []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+	for idx, hostname := range hostnames {
+		hostnamef := fldPath.Index(idx)
+		for _, msg := range validation.IsDNS1123Label(hostname) {
+			allErrs = append(allErrs, field.Invalid(hostnamef, hostname, msg))
+		}
+		if seen[hostname] {
+			allErrs = append(allErrs, field.Duplicate(hostnamef, hostname))
+		}
+		seen[hostname] = true
+	}
+	return allErrs
+}
+
+// validateAlertReceiver validates that exactly one kind of receiver is set,
+// with the fields that kind of receiver requires.
+func validateAlertReceiver(r *types.AlertReceiver, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case r.Slack == nil && r.Email == nil:
+		return append(allErrs, field.Required(fldPath, "one of slack or email is required"))
+	case r.Slack != nil && r.Email != nil:
+		return append(allErrs, field.Forbidden(fldPath, "only one of slack or email may be set"))
+	}
+
+	if s := r.Slack; s != nil {
+		slackf := fldPath.Child("slack")
+		if s.APIURL == "" {
+			allErrs = append(allErrs, field.Required(slackf.Child("apiURL"), "the webhook URL is required"))
+		} else if err := validate.URIWithProtocol(s.APIURL, "https"); err != nil {
+			allErrs = append(allErrs, field.Invalid(slackf.Child("apiURL"), s.APIURL, err.Error()))
+		}
+		if s.Channel == "" {
+			allErrs = append(allErrs, field.Required(slackf.Child("channel"), "the channel is required"))
+		}
+	}
+
+	if e := r.Email; e != nil {
+		emailf := fldPath.Child("email")
+		for _, address := range []struct {
+			name, value string
+		}{{"to", e.To}, {"from", e.From}} {
+			if address.value == "" {
+				allErrs = append(allErrs, field.Required(emailf.Child(address.name), "the address is required"))
+			} else if _, err := mail.ParseAddress(address.value); err != nil {
+				allErrs = append(allErrs, field.Invalid(emailf.Child(address.name), address.value, err.Error()))
+			}
+		}
+		if e.Smarthost == "" {
+			allErrs = append(allErrs, field.Required(emailf.Child("smarthost"), "the SMTP server is required"))
+		} else if _, _, err := net.SplitHostPort(e.Smarthost); err != nil {
+			allErrs = append(allErrs, field.Invalid(emailf.Child("smarthost"), e.Smarthost, err.Error()))
+		}
+		if e.AuthPassword != "" && e.AuthUsername == "" {
+			allErrs = append(allErrs, field.Required(emailf.Child("authUsername"), "the user is required with a password"))
+		}
+	}
+	return allErrs
+}
+
+// validateResourceQuotas validates that each quota is for a distinct
+// namespace and limits valid resources to non-negative quantities. Whether
+// the installer creates the namespaces is only known once the manifests are
+// generated.
+func validateResourceQuotas(quotas []types.NamespaceResourceQuota, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+	for idx, quota := range quotas {
+		quotaf := fldPath.Index(idx)
+		if quota.Namespace == "" {
+			allErrs = append(allErrs, field.Required(quotaf.Child("namespace"), "the namespace is required"))
+		} else if errs := validation.IsDNS1123Label(quota.Namespace); len(errs) > 0 {
+			allErrs = append(allErrs, field.Invalid(quotaf.Child("namespace"), quota.Namespace, strings.Join(errs, ", ")))
+		} else if seen[quota.Namespace] {
+			allErrs = append(allErrs, field.Duplicate(quotaf.Child("namespace"), quota.Namespace))
+		}
+		seen[quota.Namespace] = true
+
+		hardf := quotaf.Child("hard")
+		if len(quota.Hard) == 0 {
+			allErrs = append(allErrs, field.Required(hardf, "at least one limit is required"))
+			continue
+		}
+		resources := make([]string, 0, len(quota.Hard))
+		for name := range quota.Hard {
+			resources = append(resources, name)
+		}
+		sort.Strings(resources)
+		for _, name := range resources {
+			if errs := validation.IsQualifiedName(name); len(errs) > 0 {
+				allErrs = append(allErrs, field.Invalid(hardf, name, strings.Join(errs, ", ")))
+				continue
+			}
+			value := quota.Hard[name]
+			if q, err := resource.ParseQuantity(value); err != nil {
+				allErrs = append(allErrs, field.Invalid(hardf.Key(name), value, err.Error()))
+			} else if q.Sign() < 0 {
+				allErrs = append(allErrs, field.Invalid(hardf.Key(name), value, "the quantity must not be negative"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateFeatureGates validates that the enabled feature gates are named
+// and distinct.
+func validateFeatureGates(gates []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[string]bool{}
+	for idx, gate := range gates {
+		gatef := fldPath.Index(idx)
+		if gate == "" {
+			allErrs = append(allErrs, field.Required(gatef, "feature gate name is required"))
+			continue
+		}
+		if seen[gate] {
+			allErrs = append(allErrs, field.Duplicate(gatef, gate))
+		}
+		seen[gate] = true
+	}
+	return allErrs
+}
+
+func validateClusterVersionOverrides(overrides []types.ClusterVersionOverride, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	seen := map[types.ClusterVersionOverride]bool{}
+	for idx, override := range overrides {
+		overridef := fldPath.Index(idx)
+		if override.Kind == "" {
+			allErrs = append(allErrs, field.Required(overridef.Child("kind"), "kind is required"))
+		}
+		if override.Name == "" {
+			allErrs = append(allErrs, field.Required(overridef.Child("name"), "name is required"))
+		}
+		key := types.ClusterVersionOverride{
+			Kind:      override.Kind,
+			Group:     override.Group,
+			Namespace: override.Namespace,
+			Name:      override.Name,
+		}
+		if seen[key] {
+			allErrs = append(allErrs, field.Duplicate(overridef, override))
+		}
+		seen[key] = true
+	}
+	return allErrs
+}
+
 func validateNamedRepository(r string) error {
 	ref, err := dockerref.ParseNamed(r)
 	if err != nil {
@@ -169,6 +169,24 @@ func TestValidateInstallConfig(t *testing.T) {
 			}(),
 			expectedError: `^baseDomain: Invalid value: "` + fmt.Sprintf("test-cluster%042d.test-domain%056d.a%060d.b%060d.c%060d", 0, 0, 0, 0, 0) + `": must be no more than 253 characters$`,
 		},
+		{
+			name: "unread pull secret file",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.PullSecret = "@pull-secret.json"
+				return c
+			}(),
+			expectedError: `^pullSecret: Invalid value: "@pull-secret.json": `,
+		},
+		{
+			name: "missing pull secret file path",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.PullSecret = "@"
+				return c
+			}(),
+			expectedError: `^pullSecret: Required value: the pull secret file path is required$`,
+		},
 		{
 			name: "missing networking",
 			installConfig: func() *types.InstallConfig {
@@ -701,6 +719,425 @@ func TestValidateInstallConfig(t *testing.T) {
 				return c
 			}(),
 		},
+		{
+			name: "valid etcd DNS suffix",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.EtcdDNSSuffix = "internal.example.com"
+				return c
+			}(),
+		},
+		{
+			name: "invalid etcd DNS suffix",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.EtcdDNSSuffix = "-internal.example.com"
+				return c
+			}(),
+			expectedError: `^etcdDNSSuffix: Invalid value: "-internal\.example\.com": .*$`,
+		},
+		{
+			name: "valid etcd member hostnames",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ControlPlane.Replicas = pointer.Int64Ptr(3)
+				c.EtcdMemberHostnames = []string{"etcd-a", "etcd-b", "etcd-c"}
+				return c
+			}(),
+		},
+		{
+			name: "valid feature gates",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.FeatureGates = []string{"TechPreview", "CustomNoUpgrade"}
+				return c
+			}(),
+		},
+		{
+			name: "empty feature gate",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.FeatureGates = []string{"TechPreview", ""}
+				return c
+			}(),
+			expectedError: `^featureGates\[1\]: Required value: feature gate name is required$`,
+		},
+		{
+			name: "duplicate feature gate",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.FeatureGates = []string{"TechPreview", "TechPreview"}
+				return c
+			}(),
+			expectedError: `^featureGates\[1\]: Duplicate value: "TechPreview"$`,
+		},
+		{
+			name: "valid resource quotas",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ResourceQuotas = []types.NamespaceResourceQuota{
+					{Namespace: "openshift-machine-config-operator", Hard: map[string]string{"requests.cpu": "4", "requests.memory": "8Gi", "count/deployments.apps": "10"}},
+					{Namespace: "openshift-etcd", Hard: map[string]string{"pods": "10"}},
+				}
+				return c
+			}(),
+		},
+		{
+			name: "resource quota without namespace",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ResourceQuotas = []types.NamespaceResourceQuota{{Hard: map[string]string{"pods": "10"}}}
+				return c
+			}(),
+			expectedError: `^resourceQuotas\[0\]\.namespace: Required value: the namespace is required$`,
+		},
+		{
+			name: "duplicate resource quota namespace",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ResourceQuotas = []types.NamespaceResourceQuota{
+					{Namespace: "openshift-etcd", Hard: map[string]string{"pods": "10"}},
+					{Namespace: "openshift-etcd", Hard: map[string]string{"pods": "20"}},
+				}
+				return c
+			}(),
+			expectedError: `^resourceQuotas\[1\]\.namespace: Duplicate value: "openshift-etcd"$`,
+		},
+		{
+			name: "resource quota without limits",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ResourceQuotas = []types.NamespaceResourceQuota{{Namespace: "openshift-etcd"}}
+				return c
+			}(),
+			expectedError: `^resourceQuotas\[0\]\.hard: Required value: at least one limit is required$`,
+		},
+		{
+			name: "invalid resource quota quantity",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ResourceQuotas = []types.NamespaceResourceQuota{{Namespace: "openshift-etcd", Hard: map[string]string{"requests.memory": "8GB"}}}
+				return c
+			}(),
+			expectedError: `^resourceQuotas\[0\]\.hard\[requests\.memory\]: Invalid value: "8GB": quantities must match the regular expression .*$`,
+		},
+		{
+			name: "negative resource quota quantity",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ResourceQuotas = []types.NamespaceResourceQuota{{Namespace: "openshift-etcd", Hard: map[string]string{"pods": "-1"}}}
+				return c
+			}(),
+			expectedError: `^resourceQuotas\[0\]\.hard\[pods\]: Invalid value: "-1": the quantity must not be negative$`,
+		},
+		{
+			name: "too few etcd member hostnames",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ControlPlane.Replicas = pointer.Int64Ptr(3)
+				c.EtcdMemberHostnames = []string{"etcd-a", "etcd-b"}
+				return c
+			}(),
+			expectedError: `^etcdMemberHostnames: Invalid value: \[\]string{"etcd-a", "etcd-b"}: 3 hostnames are required, one for each control plane replica$`,
+		},
+		{
+			name: "too many etcd member hostnames",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.EtcdMemberHostnames = []string{"etcd-a", "etcd-b"}
+				return c
+			}(),
+			expectedError: `^etcdMemberHostnames: Invalid value: \[\]string{"etcd-a", "etcd-b"}: 1 hostnames are required, one for each control plane replica$`,
+		},
+		{
+			name: "invalid etcd member hostname",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.EtcdMemberHostnames = []string{"etcd.a"}
+				return c
+			}(),
+			expectedError: `^etcdMemberHostnames\[0\]: Invalid value: "etcd\.a": .*$`,
+		},
+		{
+			name: "duplicate etcd member hostname",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ControlPlane.Replicas = pointer.Int64Ptr(2)
+				c.EtcdMemberHostnames = []string{"etcd-a", "etcd-a"}
+				return c
+			}(),
+			expectedError: `^etcdMemberHostnames\[1\]: Duplicate value: "etcd-a"$`,
+		},
+		{
+			name: "etcd member hostnames with an external etcd",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.EtcdMemberHostnames = []string{"etcd-a"}
+				c.ExternalEtcd = &types.ExternalEtcd{
+					Hostnames: []string{"etcd-0"},
+					CABundle:  testExternalEtcdCABundle,
+				}
+				return c
+			}(),
+			expectedError: `^etcdMemberHostnames: Forbidden: etcdMemberHostnames cannot be set for an external etcd, whose members are named by externalEtcd\.hostnames$`,
+		},
+		{
+			name: "valid external etcd",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ExternalEtcd = &types.ExternalEtcd{
+					Hostnames: []string{"etcd-0", "etcd-1", "etcd-2"},
+					CABundle:  testExternalEtcdCABundle,
+				}
+				return c
+			}(),
+		},
+		{
+			name: "valid etcd backup",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.EtcdBackup = &types.EtcdBackup{
+					Schedule: "30 2 * * *",
+					Image:    "quay.io/example/etcd:v3.3",
+				}
+				return c
+			}(),
+		},
+		{
+			name: "etcd backup invalid schedule",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.EtcdBackup = &types.EtcdBackup{
+					Schedule: "30 25 * * *",
+					Image:    "quay.io/example/etcd:v3.3",
+				}
+				return c
+			}(),
+			expectedError: `^etcdBackup\.schedule: Invalid value: "30 25 \* \* \*": invalid hour "25": 25 is not between 0 and 23$`,
+		},
+		{
+			name: "etcd backup missing schedule",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.EtcdBackup = &types.EtcdBackup{
+					Image: "quay.io/example/etcd:v3.3",
+				}
+				return c
+			}(),
+			expectedError: `^etcdBackup\.schedule: Required value: schedule is required for etcd backups$`,
+		},
+		{
+			name: "etcd backup missing image",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.EtcdBackup = &types.EtcdBackup{
+					Schedule: "@daily",
+				}
+				return c
+			}(),
+			expectedError: `^etcdBackup\.image: Required value: image is required for etcd backups$`,
+		},
+		{
+			name: "etcd backup with an external etcd",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.EtcdBackup = &types.EtcdBackup{
+					Schedule: "@daily",
+					Image:    "quay.io/example/etcd:v3.3",
+				}
+				c.ExternalEtcd = &types.ExternalEtcd{
+					Hostnames: []string{"etcd-0"},
+					CABundle:  testExternalEtcdCABundle,
+				}
+				return c
+			}(),
+			expectedError: `^etcdBackup: Forbidden: etcdBackup cannot be set for an external etcd, which is not backed up by the cluster$`,
+		},
+		{
+			name: "valid Slack alert receiver",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.AlertReceiver = &types.AlertReceiver{
+					Slack: &types.SlackAlertReceiver{
+						APIURL:  "https://hooks.slack.com/services/T0/B0/XXXX",
+						Channel: "#alerts",
+					},
+				}
+				return c
+			}(),
+		},
+		{
+			name: "Slack alert receiver missing channel",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.AlertReceiver = &types.AlertReceiver{
+					Slack: &types.SlackAlertReceiver{
+						APIURL: "https://hooks.slack.com/services/T0/B0/XXXX",
+					},
+				}
+				return c
+			}(),
+			expectedError: `^alertReceiver\.slack\.channel: Required value: the channel is required$`,
+		},
+		{
+			name: "Slack alert receiver with an http URL",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.AlertReceiver = &types.AlertReceiver{
+					Slack: &types.SlackAlertReceiver{
+						APIURL:  "http://hooks.slack.com/services/T0/B0/XXXX",
+						Channel: "#alerts",
+					},
+				}
+				return c
+			}(),
+			expectedError: `^alertReceiver\.slack\.apiURL: Invalid value: "http://hooks\.slack\.com/services/T0/B0/XXXX": must use https protocol$`,
+		},
+		{
+			name: "valid email alert receiver",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.AlertReceiver = &types.AlertReceiver{
+					Email: &types.EmailAlertReceiver{
+						To:           "oncall@example.com",
+						From:         "alertmanager@example.com",
+						Smarthost:    "smtp.example.com:587",
+						AuthUsername: "alertmanager",
+						AuthPassword: "password",
+					},
+				}
+				return c
+			}(),
+		},
+		{
+			name: "email alert receiver missing smarthost",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.AlertReceiver = &types.AlertReceiver{
+					Email: &types.EmailAlertReceiver{
+						To:   "oncall@example.com",
+						From: "alertmanager@example.com",
+					},
+				}
+				return c
+			}(),
+			expectedError: `^alertReceiver\.email\.smarthost: Required value: the SMTP server is required$`,
+		},
+		{
+			name: "alert receiver of no kind",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.AlertReceiver = &types.AlertReceiver{}
+				return c
+			}(),
+			expectedError: `^alertReceiver: Required value: one of slack or email is required$`,
+		},
+		{
+			name: "external etcd missing hostnames",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ExternalEtcd = &types.ExternalEtcd{
+					CABundle: testExternalEtcdCABundle,
+				}
+				return c
+			}(),
+			expectedError: `^externalEtcd\.hostnames: Required value: hostnames are required for an external etcd$`,
+		},
+		{
+			name: "external etcd missing CA bundle",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ExternalEtcd = &types.ExternalEtcd{
+					Hostnames: []string{"etcd-0"},
+				}
+				return c
+			}(),
+			expectedError: `^externalEtcd\.caBundle: Required value: caBundle is required for an external etcd$`,
+		},
+		{
+			name: "external etcd invalid hostname",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ExternalEtcd = &types.ExternalEtcd{
+					Hostnames: []string{"etcd-0", "etcd.1"},
+					CABundle:  testExternalEtcdCABundle,
+				}
+				return c
+			}(),
+			expectedError: `^externalEtcd\.hostnames\[1\]: Invalid value: "etcd\.1": .*$`,
+		},
+		{
+			name: "external etcd duplicate hostname",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ExternalEtcd = &types.ExternalEtcd{
+					Hostnames: []string{"etcd-0", "etcd-0"},
+					CABundle:  testExternalEtcdCABundle,
+				}
+				return c
+			}(),
+			expectedError: `^externalEtcd\.hostnames\[1\]: Duplicate value: "etcd-0"$`,
+		},
+		{
+			name: "external etcd invalid CA bundle",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ExternalEtcd = &types.ExternalEtcd{
+					Hostnames: []string{"etcd-0"},
+					CABundle:  "not a certificate",
+				}
+				return c
+			}(),
+			expectedError: `^externalEtcd\.caBundle: Invalid value: "not a certificate": .*$`,
+		},
+		{
+			name: "valid cluster version override",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ClusterVersionOverrides = []types.ClusterVersionOverride{{
+					Kind:      "Deployment",
+					Group:     "apps",
+					Namespace: "openshift-test",
+					Name:      "test-operator",
+					Unmanaged: true,
+				}}
+				return c
+			}(),
+		},
+		{
+			name: "cluster version override missing kind and name",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ClusterVersionOverrides = []types.ClusterVersionOverride{{
+					Group: "apps",
+				}}
+				return c
+			}(),
+			expectedError: `^\[clusterVersionOverrides\[0\]\.kind: Required value: kind is required, clusterVersionOverrides\[0\]\.name: Required value: name is required\]$`,
+		},
+		{
+			name: "duplicate cluster version override",
+			installConfig: func() *types.InstallConfig {
+				c := validInstallConfig()
+				c.ClusterVersionOverrides = []types.ClusterVersionOverride{
+					{
+						Kind:      "Deployment",
+						Group:     "apps",
+						Namespace: "openshift-test",
+						Name:      "test-operator",
+						Unmanaged: true,
+					},
+					{
+						Kind:      "Deployment",
+						Group:     "apps",
+						Namespace: "openshift-test",
+						Name:      "test-operator",
+					},
+				}
+				return c
+			}(),
+			expectedError: `^clusterVersionOverrides\[1\]: Duplicate value: .*$`,
+		},
 		{
 			name: "invalid publishing strategy",
 			installConfig: func() *types.InstallConfig {
@@ -732,3 +1169,39 @@ func TestValidateInstallConfig(t *testing.T) {
 		})
 	}
 }
+
+const testExternalEtcdCABundle = `-----BEGIN CERTIFICATE-----
+MIIF2zCCA8OgAwIBAgICEAAwDQYJKoZIhvcNAQELBQAwgYExCzAJBgNVBAYTAlVT
+MRcwFQYDVQQIDA5Ob3J0aCBDYXJvbGluYTEQMA4GA1UEBwwHUmFsZWlnaDEUMBIG
+A1UECgwLUmVkIEhhdCBJbmMxHzAdBgNVBAsMFk9wZW5TaGlmdCBJbnN0YWxsIFRl
+c3QxEDAOBgNVBAMMB1Jvb3QgQ0EwHhcNMTkwNzIyMjAwNzUxWhcNMjkwNzE5MjAw
+NzUxWjB3MQswCQYDVQQGEwJVUzEXMBUGA1UECAwOTm9ydGggQ2Fyb2xpbmExFDAS
+BgNVBAoMC1JlZCBIYXQgSW5jMR8wHQYDVQQLDBZPcGVuU2hpZnQgSW5zdGFsbCBU
+ZXN0MRgwFgYDVQQDDA9JbnRlcm1lZGlhdGUgQ0EwggIiMA0GCSqGSIb3DQEBAQUA
+A4ICDwAwggIKAoICAQDZhc69vEq9XyG+vcOW4rPx9aYJgn7NFXaE88xrKajFyu2v
+kD5Mz7geQV/RQKp1RMvj/1JCW5Npw8QwoPXNGQ8M+d+ajGgSkUZNVBQRXiR/hpfK
+ohox9gJRsOVCAvhyE15iZHkEVFFcchiWbsTM9QllLsiiI0qZ/QpkUmJmDyXUV4Hq
+hoAGXsojp0xaEQhrl+Hayiwao7qZkbKFCbNIDFU++ZDNT41qqDwcYmbkBJgYoGdS
+IAk4Mjf7+rLJPXWNYtYB3g1cuN4pH8FkFT9zocNr0xrsx2itY4gvXgIe/vzts8aw
+sHx1h2HcZK7iJEHs25QGrsZhiADeb0i5pN1kaPqpY0qgQUCIaqZAtMMeHXQ0k3PB
+xTz8vk0388oFLaJFuI0P9Q6CRf5+4rc9O201aUIuue3Y4IS6zAcd8yL5d5vxvCiN
+Dbl7YenBS4C9xSEEiVZwN7AtIdKFq5pGrlptmhVbGFW1CLQNsVWpetCY12Sh9FOq
+2IBaAup+XgRgO4kHs3t7euVaS2viH3MplPsOUim8NZPZBdZkTtS3W9SynBDriy1d
+KtrYgz0zrgEAa82mq4INaR+7Utct97zhKa1zM47KlHgkauiTPkUcqVhoNWxdM5tI
+nSWym/9pPHUmzt8v/F8COA/8Xv+db2QX14S3fStI+8mp084RWuevtbh5WcoypQID
+AQABo2YwZDAdBgNVHQ4EFgQUPUqJPYDZeUXbBlR0xXA/F+DYYagwHwYDVR0jBBgw
+FoAUjWflPh3KYZ5o3BP3Po4v2ZBshVkwEgYDVR0TAQH/BAgwBgEB/wIBADAOBgNV
+HQ8BAf8EBAMCAYYwDQYJKoZIhvcNAQELBQADggIBAH665ntrBhyf+MPFnkY+1VUr
+VrfRlP4SccoujdLB/sUKqydYsED+mDJ+V8uFOgoi7PHqwvsRS+yR/bB0bNNYSfKY
+slCMQA3sJ7SNDPBsec955ehYPNdquhem+oICzgFaQwL9ULDG87fKZjmaKO25dIYX
+ttLqn+0b0GjpfQRuZ3NpAnCTWevodc5A3aYQm6vYeCyeIHGPpmtLE6oPRFib7wtD
+n4DFVM57F34ClnnF4m8jq9HoTcM1Y3qOFyslK/4FRyx3HXbEVsm5L289l0AS866U
+WEVM9DCqpFNLTwRk0mn4mspNcRxTDUTiHAxMhKxHGgbPcFzCJXqZzkW56bDcAGA5
+sQr+MOfa1P/K7pVcFtOAhsBi5ff1G4t1G1+amqXEDalL+qKRGFugGVf+poyb2C3g
+sfxkPBp9jPPMgMzXULQglwU4IUm8GtBb9Lh6AFPvt78XAWvNvHLP1Rf8JNZ9prx5
+N9RzIKSWKm6CVEjSDvQ42j4OpW0eecHAoluZFMrykVl+KmapWUwQF6v0xz1RJdQ+
+q3vGJ6shhiFd6y0ygxPwMaEjhhpbRy4tK9iDBj5yRpo+HE5X+FQSN6NHOYWMeDoZ
+uzd86/huEH5qIAL4unM9YFTzJ4CFOC8EJMDW6ul0uKjOwGPP3R1Vss6sC7kR0gXI
+rLWYdt40z0pjcR3FDVzh
+-----END CERTIFICATE-----
+`
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"net"
 	"net/url"
+	"strconv"
 	"strings"
 
 	"golang.org/x/crypto/ssh"
@@ -184,3 +185,98 @@ func MAC(addr string) error {
 	_, err := net.ParseMAC(addr)
 	return err
 }
+
+// cronDescriptors are the predefined schedules accepted in place of the five
+// fields of a cron schedule.
+var cronDescriptors = map[string]bool{
+	"@yearly":   true,
+	"@annually": true,
+	"@monthly":  true,
+	"@weekly":   true,
+	"@daily":    true,
+	"@midnight": true,
+	"@hourly":   true,
+}
+
+// cronField describes the values of a field of a cron schedule.
+type cronField struct {
+	name     string
+	min, max int
+	// names are the symbolic names of the values, starting with min.
+	names []string
+}
+
+var cronFields = []cronField{
+	{name: "minute", min: 0, max: 59},
+	{name: "hour", min: 0, max: 23},
+	{name: "day of month", min: 1, max: 31},
+	{name: "month", min: 1, max: 12, names: []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}},
+	{name: "day of week", min: 0, max: 6, names: []string{"sun", "mon", "tue", "wed", "thu", "fri", "sat"}},
+}
+
+// CronSchedule checks if the given string is a schedule in the cron format
+// of the Kubernetes CronJob: five fields, for the minute, hour, day of month,
+// month and day of week, each a comma-separated list of *, values and
+// ranges with an optional /step. A predefined schedule such as @daily is
+// also accepted.
+func CronSchedule(v string) error {
+	if cronDescriptors[v] {
+		return nil
+	}
+	fields := strings.Fields(v)
+	if len(fields) != len(cronFields) {
+		return fmt.Errorf("expected %d fields, found %d", len(cronFields), len(fields))
+	}
+	for i, field := range fields {
+		for _, item := range strings.Split(field, ",") {
+			if err := cronFields[i].validate(item); err != nil {
+				return fmt.Errorf("invalid %s %q: %v", cronFields[i].name, field, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (f *cronField) validate(item string) error {
+	valueRange, step := item, ""
+	if i := strings.Index(item, "/"); i >= 0 {
+		valueRange, step = item[:i], item[i+1:]
+		if n, err := strconv.Atoi(step); err != nil || n <= 0 {
+			return fmt.Errorf("invalid step %q", step)
+		}
+	}
+	if valueRange == "*" || valueRange == "?" {
+		return nil
+	}
+	bounds := strings.SplitN(valueRange, "-", 2)
+	start, err := f.value(bounds[0])
+	if err != nil {
+		return err
+	}
+	if len(bounds) == 2 {
+		end, err := f.value(bounds[1])
+		if err != nil {
+			return err
+		}
+		if end < start {
+			return fmt.Errorf("range %q ends before it starts", valueRange)
+		}
+	}
+	return nil
+}
+
+func (f *cronField) value(v string) (int, error) {
+	for i, name := range f.names {
+		if strings.EqualFold(v, name) {
+			return f.min + i, nil
+		}
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a number", v)
+	}
+	if n < f.min || n > f.max {
+		return 0, fmt.Errorf("%d is not between %d and %d", n, f.min, f.max)
+	}
+	return n, nil
+}
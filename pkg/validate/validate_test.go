@@ -571,3 +571,43 @@ func TestURI(t *testing.T) {
 		})
 	}
 }
+
+func TestCronSchedule(t *testing.T) {
+	cases := []struct {
+		name     string
+		schedule string
+		valid    bool
+	}{
+		{"every minute", "* * * * *", true},
+		{"daily", "0 0 * * *", true},
+		{"descriptor", "@daily", true},
+		{"steps", "*/15 0-12/2 * * *", true},
+		{"lists", "0,30 6,18 1,15 * 1-5", true},
+		{"names", "0 3 * jan-jun sun", true},
+		{"question mark", "0 0 ? * *", true},
+		{"extra whitespace", " 0  0 * * * ", true},
+		{"empty", "", false},
+		{"too few fields", "0 0 * *", false},
+		{"with seconds", "0 0 0 * * *", false},
+		{"unknown descriptor", "@fortnightly", false},
+		{"minute out of range", "60 0 * * *", false},
+		{"hour out of range", "0 24 * * *", false},
+		{"day of month zero", "0 0 0 * *", false},
+		{"day of week out of range", "0 0 * * 7", false},
+		{"reversed range", "0 10-2 * * *", false},
+		{"zero step", "*/0 * * * *", false},
+		{"invalid step", "*/x * * * *", false},
+		{"not a number", "a * * * *", false},
+		{"empty list item", "0, * * * *", false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := CronSchedule(tc.schedule)
+			if tc.valid {
+				assert.NoError(t, err)
+			} else {
+				assert.Error(t, err)
+			}
+		})
+	}
+}